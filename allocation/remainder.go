@@ -0,0 +1,92 @@
+package allocation
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+)
+
+// Remainder selects who receives the rounding dust left after the floored
+// pro-rata shares are assigned.
+type Remainder string
+
+const (
+	// RemainderLast gives all the dust to the highest address.
+	RemainderLast Remainder = "last"
+	// RemainderLargest gives one unit each to the addresses with the largest
+	// fractional remainders (Hamilton apportionment), ties broken by address.
+	RemainderLargest Remainder = "largest-remainder"
+)
+
+// ParseRemainder validates a remainder rule name.
+func ParseRemainder(s string) (Remainder, error) {
+	switch r := Remainder(s); r {
+	case RemainderLast, RemainderLargest:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown remainder rule %q", s)
+	}
+}
+
+// sortedAddrs returns the addresses of m in ascending byte order.
+func sortedAddrs(m map[common.Address]*big.Int) []common.Address {
+	addrs := make([]common.Address, 0, len(m))
+	for addr := range m {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	return addrs
+}
+
+// Apportion splits totalAmount proportionally to weights so that the shares
+// add up to totalAmount exactly. The result does not depend on map order.
+func Apportion(weights map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	if totalAmount.Sign() < 0 {
+		return nil, fmt.Errorf("negative total amount %s", totalAmount)
+	}
+
+	totalWeight := big.NewInt(0)
+	for addr, weight := range weights {
+		if weight.Sign() < 0 {
+			return nil, fmt.Errorf("negative weight for %s: %s", addr, weight)
+		}
+		totalWeight = big.NewInt(0).Add(totalWeight, weight)
+	}
+	if totalWeight.Sign() == 0 {
+		return nil, fmt.Errorf("total weight is zero")
+	}
+
+	addrs := sortedAddrs(weights)
+	rewards := make(map[common.Address]*big.Int, len(addrs))
+	remainders := make(map[common.Address]*big.Int, len(addrs))
+	totalAssigned := big.NewInt(0)
+	for _, addr := range addrs {
+		reward, rem := big.NewInt(0).DivMod(big.NewInt(0).Mul(totalAmount, weights[addr]), totalWeight, big.NewInt(0))
+		rewards[addr] = reward
+		remainders[addr] = rem
+		totalAssigned = big.NewInt(0).Add(totalAssigned, reward)
+	}
+
+	left := big.NewInt(0).Sub(totalAmount, totalAssigned)
+	switch remainder {
+	case RemainderLast:
+		last := addrs[len(addrs)-1]
+		rewards[last] = big.NewInt(0).Add(rewards[last], left)
+	case RemainderLargest:
+		// left is smaller than the number of addresses, one unit each suffices.
+		sort.SliceStable(addrs, func(i, j int) bool {
+			return remainders[addrs[i]].Cmp(remainders[addrs[j]]) > 0
+		})
+		for i := int64(0); i < left.Int64(); i++ {
+			rewards[addrs[i]] = big.NewInt(0).Add(rewards[addrs[i]], big.NewInt(1))
+		}
+	default:
+		return nil, fmt.Errorf("unknown remainder rule %q", remainder)
+	}
+
+	return rewards, nil
+}
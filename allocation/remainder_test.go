@@ -0,0 +1,59 @@
+package allocation
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+)
+
+var (
+	addr1 = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	addr2 = common.HexToAddress("0x0000000000000000000000000000000000000002")
+	addr3 = common.HexToAddress("0x0000000000000000000000000000000000000003")
+)
+
+func weights(values ...int64) map[common.Address]*big.Int {
+	addrs := []common.Address{addr1, addr2, addr3}
+	m := make(map[common.Address]*big.Int, len(values))
+	for i, v := range values {
+		m[addrs[i]] = big.NewInt(v)
+	}
+	return m
+}
+
+func TestApportion(t *testing.T) {
+	cases := []struct {
+		weights   map[common.Address]*big.Int
+		total     int64
+		remainder Remainder
+		expected  map[common.Address]int64
+	}{
+		// 10*1/3 = 3.33, 10*2/3 = 6.66: the dust goes to addr2 (largest
+		// remainder) or addr2 (highest address).
+		{weights(1, 2), 10, RemainderLargest, map[common.Address]int64{addr1: 3, addr2: 7}},
+		{weights(1, 2), 10, RemainderLast, map[common.Address]int64{addr1: 3, addr2: 7}},
+		// equal remainders are broken by address.
+		{weights(1, 1, 1), 10, RemainderLargest, map[common.Address]int64{addr1: 4, addr2: 3, addr3: 3}},
+		{weights(1, 1, 1), 11, RemainderLargest, map[common.Address]int64{addr1: 4, addr2: 4, addr3: 3}},
+		{weights(1, 1, 1), 11, RemainderLast, map[common.Address]int64{addr1: 3, addr2: 3, addr3: 5}},
+		{weights(5, 0, 3), 8, RemainderLargest, map[common.Address]int64{addr1: 5, addr2: 0, addr3: 3}},
+	}
+
+	for i, c := range cases {
+		for run := 0; run < 10; run++ {
+			rewards, err := Apportion(c.weights, big.NewInt(c.total), c.remainder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for addr, expected := range c.expected {
+				if rewards[addr].Int64() != expected {
+					t.Fatalf("case %d: reward of %s is %s, want %d", i, addr, rewards[addr], expected)
+				}
+			}
+		}
+	}
+
+	if _, err := Apportion(weights(0, 0), big.NewInt(1), RemainderLargest); err == nil {
+		t.Fatal("expected error for zero total weight")
+	}
+}
@@ -0,0 +1,254 @@
+package allocation
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Strategy allocates totalAmount between addresses according to their points.
+// The returned rewards always add up to totalAmount exactly.
+type Strategy interface {
+	Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error)
+}
+
+// ParseStrategy parses a strategy spec:
+//
+//	pro-rata                     rewards proportional to points
+//	sqrt                         rewards proportional to the square root of points
+//	cap:<amount>                 pro-rata, no address gets more than amount
+//	floor:<amount>               pro-rata, every address gets at least amount
+//	tiered:<points>=<mult>,...   pro-rata on points × the multiplier of the highest tier reached
+func ParseStrategy(spec string) (Strategy, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+	switch name {
+	case "", "pro-rata":
+		return ProRata{}, nil
+	case "sqrt":
+		return Sqrt{}, nil
+	case "cap":
+		max, ok := big.NewInt(0).SetString(arg, 10)
+		if !ok || max.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid cap amount %q", arg)
+		}
+		return Cap{Max: max}, nil
+	case "floor":
+		min, ok := big.NewInt(0).SetString(arg, 10)
+		if !ok || min.Sign() < 0 {
+			return nil, fmt.Errorf("invalid floor amount %q", arg)
+		}
+		return Floor{Min: min}, nil
+	case "tiered":
+		return parseTiered(arg)
+	default:
+		return nil, fmt.Errorf("unknown allocation strategy %q", spec)
+	}
+}
+
+// ProRata allocates proportionally to points.
+type ProRata struct{}
+
+func (ProRata) Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	return Apportion(points, totalAmount, remainder)
+}
+
+// Sqrt allocates proportionally to the integer square root of points, which
+// dampens the share of the largest holders.
+type Sqrt struct{}
+
+func (Sqrt) Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	weights := make(map[common.Address]*big.Int, len(points))
+	for addr, point := range points {
+		if point.Sign() < 0 {
+			return nil, fmt.Errorf("negative points for %s: %s", addr, point)
+		}
+		weights[addr] = big.NewInt(0).Sqrt(point)
+	}
+	return Apportion(weights, totalAmount, remainder)
+}
+
+// Cap allocates pro-rata but limits every reward to Max. The excess is
+// redistributed pro-rata between the addresses still below the cap.
+type Cap struct {
+	Max *big.Int
+}
+
+func (c Cap) Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	rewards := make(map[common.Address]*big.Int, len(points))
+	open := copyPoints(points)
+	left := big.NewInt(0).Set(totalAmount)
+	for {
+		shares, err := Apportion(open, left, remainder)
+		if err != nil {
+			return nil, err
+		}
+
+		capped := false
+		for addr, share := range shares {
+			if share.Cmp(c.Max) > 0 {
+				rewards[addr] = big.NewInt(0).Set(c.Max)
+				left = big.NewInt(0).Sub(left, c.Max)
+				delete(open, addr)
+				capped = true
+			}
+		}
+		if !capped {
+			for addr, share := range shares {
+				rewards[addr] = share
+			}
+			return rewards, nil
+		}
+		if nonZero(open) == 0 {
+			if left.Sign() != 0 {
+				return nil, fmt.Errorf("cap %s too low to distribute %s between %d addresses", c.Max, totalAmount, len(points))
+			}
+			for addr := range open {
+				rewards[addr] = big.NewInt(0)
+			}
+			return rewards, nil
+		}
+	}
+}
+
+// Floor allocates pro-rata but guarantees Min to every address with points.
+// The floors are funded pro-rata by the addresses above them.
+type Floor struct {
+	Min *big.Int
+}
+
+func (f Floor) Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	n := big.NewInt(int64(nonZero(points)))
+	if big.NewInt(0).Mul(n, f.Min).Cmp(totalAmount) > 0 {
+		return nil, fmt.Errorf("floor %s too high to distribute %s between %s addresses", f.Min, totalAmount, n)
+	}
+
+	rewards := make(map[common.Address]*big.Int, len(points))
+	open := copyPoints(points)
+	for addr, point := range open {
+		if point.Sign() == 0 {
+			rewards[addr] = big.NewInt(0)
+			delete(open, addr)
+		}
+	}
+	left := big.NewInt(0).Set(totalAmount)
+	for {
+		shares, err := Apportion(open, left, remainder)
+		if err != nil {
+			return nil, err
+		}
+
+		raised := false
+		for addr, share := range shares {
+			if share.Cmp(f.Min) < 0 {
+				rewards[addr] = big.NewInt(0).Set(f.Min)
+				left = big.NewInt(0).Sub(left, f.Min)
+				delete(open, addr)
+				raised = true
+			}
+		}
+		if !raised {
+			for addr, share := range shares {
+				rewards[addr] = share
+			}
+			return rewards, nil
+		}
+	}
+}
+
+// Tier applies Multiplier (in basis points) to addresses with at least
+// MinPoints points.
+type Tier struct {
+	MinPoints  *big.Int
+	Multiplier int64
+}
+
+// Tiered allocates pro-rata on points weighted by the multiplier of the
+// highest tier each address reaches. Addresses below every tier get 1×.
+type Tiered struct {
+	Tiers []Tier
+}
+
+func (t Tiered) Allocate(points map[common.Address]*big.Int, totalAmount *big.Int, remainder Remainder) (map[common.Address]*big.Int, error) {
+	tiers := make([]Tier, len(t.Tiers))
+	copy(tiers, t.Tiers)
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinPoints.Cmp(tiers[j].MinPoints) < 0
+	})
+
+	weights := make(map[common.Address]*big.Int, len(points))
+	for addr, point := range points {
+		multiplier := int64(basisPoints)
+		for _, tier := range tiers {
+			if point.Cmp(tier.MinPoints) >= 0 {
+				multiplier = tier.Multiplier
+			}
+		}
+		weights[addr] = big.NewInt(0).Mul(point, big.NewInt(multiplier))
+	}
+	return Apportion(weights, totalAmount, remainder)
+}
+
+// Scaled returns the tiers with MinPoints multiplied by factor, for points in a
+// finer unit than the thresholds were given in.
+func (t Tiered) Scaled(factor *big.Int) Tiered {
+	scaled := Tiered{Tiers: make([]Tier, len(t.Tiers))}
+	for i, tier := range t.Tiers {
+		scaled.Tiers[i] = Tier{MinPoints: big.NewInt(0).Mul(tier.MinPoints, factor), Multiplier: tier.Multiplier}
+	}
+	return scaled
+}
+
+const basisPoints = 10000
+
+func parseTiered(arg string) (Tiered, error) {
+	tiered := Tiered{}
+	for _, tierSpec := range strings.Split(arg, ",") {
+		minPoints, mult, ok := strings.Cut(tierSpec, "=")
+		if !ok {
+			return Tiered{}, fmt.Errorf("invalid tier %q", tierSpec)
+		}
+		min, ok := big.NewInt(0).SetString(minPoints, 10)
+		if !ok || min.Sign() < 0 {
+			return Tiered{}, fmt.Errorf("invalid tier points %q", minPoints)
+		}
+		multiplier, err := ParseMultiplier(mult)
+		if err != nil {
+			return Tiered{}, err
+		}
+		tiered.Tiers = append(tiered.Tiers, Tier{MinPoints: min, Multiplier: multiplier})
+	}
+	return tiered, nil
+}
+
+// ParseMultiplier parses a decimal multiplier such as 1.25 into basis points.
+func ParseMultiplier(s string) (int64, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() < 0 {
+		return 0, fmt.Errorf("invalid multiplier %q", s)
+	}
+	r.Mul(r, big.NewRat(basisPoints, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("multiplier %q has more than 4 decimals", s)
+	}
+	return r.Num().Int64(), nil
+}
+
+func copyPoints(points map[common.Address]*big.Int) map[common.Address]*big.Int {
+	m := make(map[common.Address]*big.Int, len(points))
+	for addr, point := range points {
+		m[addr] = point
+	}
+	return m
+}
+
+func nonZero(points map[common.Address]*big.Int) int {
+	n := 0
+	for _, point := range points {
+		if point.Sign() != 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,65 @@
+package allocation
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+)
+
+func TestStrategies(t *testing.T) {
+	cases := []struct {
+		spec     string
+		points   map[common.Address]*big.Int
+		total    int64
+		expected map[common.Address]int64
+	}{
+		{"pro-rata", weights(1, 3), 100, map[common.Address]int64{addr1: 25, addr2: 75}},
+		{"sqrt", weights(100, 900), 100, map[common.Address]int64{addr1: 25, addr2: 75}},
+		// 10/80/10 capped at 50: the 30 excess is split 1:1.
+		{"cap:50", weights(10, 80, 10), 100, map[common.Address]int64{addr1: 25, addr2: 50, addr3: 25}},
+		// 1/1/98 with floor 10: the floors are funded by addr3.
+		{"floor:10", weights(1, 1, 98), 100, map[common.Address]int64{addr1: 10, addr2: 10, addr3: 80}},
+		// addr2 reaches the 2× tier: weights 10, 40.
+		{"tiered:0=1,20=2", weights(10, 20), 100, map[common.Address]int64{addr1: 20, addr2: 80}},
+		{"tiered:20=1.5", weights(10, 20), 40, map[common.Address]int64{addr1: 10, addr2: 30}},
+	}
+
+	for _, c := range cases {
+		strategy, err := ParseStrategy(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rewards, err := strategy.Allocate(c.points, big.NewInt(c.total), RemainderLargest)
+		if err != nil {
+			t.Fatalf("%s: %v", c.spec, err)
+		}
+
+		sum := big.NewInt(0)
+		for _, reward := range rewards {
+			sum.Add(sum, reward)
+		}
+		if sum.Int64() != c.total {
+			t.Errorf("%s: sum %s, want %d", c.spec, sum, c.total)
+		}
+		for addr, expected := range c.expected {
+			if rewards[addr].Int64() != expected {
+				t.Errorf("%s: reward of %s is %s, want %d", c.spec, addr, rewards[addr], expected)
+			}
+		}
+	}
+}
+
+func TestStrategyLimits(t *testing.T) {
+	if _, err := (Cap{Max: big.NewInt(10)}).Allocate(weights(1, 1), big.NewInt(30), RemainderLargest); err == nil {
+		t.Error("expected error when the caps cannot absorb the total")
+	}
+	if _, err := (Floor{Min: big.NewInt(20)}).Allocate(weights(1, 1), big.NewInt(30), RemainderLargest); err == nil {
+		t.Error("expected error when the floors exceed the total")
+	}
+	for _, spec := range []string{"cap:0", "floor:x", "tiered:1", "tiered:1=1.00001", "unknown"} {
+		if _, err := ParseStrategy(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
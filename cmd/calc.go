@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/bloxapp/ssv-rewards/allocation"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"math/big"
@@ -17,6 +18,14 @@ var (
 	nethSsvRewardAmount  string
 	rnethSsvRewardAmount string
 	outputDir            string
+	remainderRule        string
+	nethAllocation       string
+	rnethAllocation      string
+	poolFlags            []string
+	poolsConfigPath      string
+	finalName            string
+	rewardToken          string
+	rewardRound          uint64
 )
 
 func init() {
@@ -24,7 +33,15 @@ func init() {
 	calcCmd.PersistentFlags().StringVarP(&rnethPointsInputPath, "rnethPointsInputPath", "", "", "rneth points input file path")
 	calcCmd.PersistentFlags().StringVarP(&nethSsvRewardAmount, "nethSsvRewardAmount", "", "", "ssv reward amount")
 	calcCmd.PersistentFlags().StringVarP(&rnethSsvRewardAmount, "rnethSsvRewardAmount", "", "", "ssv reward amount")
+	calcCmd.PersistentFlags().StringArrayVarP(&poolFlags, "pool", "", nil, "pool as name:pointsPath:amount[:allocation], repeatable")
+	calcCmd.PersistentFlags().StringVarP(&poolsConfigPath, "poolsConfig", "", "", "pools config file path")
+	calcCmd.PersistentFlags().StringVarP(&finalName, "finalName", "", "final", "name of the merged reward file")
+	calcCmd.PersistentFlags().StringVarP(&rewardToken, "rewardToken", "", "ssv", "reward token recorded in the reward files")
+	calcCmd.PersistentFlags().Uint64VarP(&rewardRound, "round", "", 0, "reward round recorded in the reward files")
 	calcCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+	calcCmd.PersistentFlags().StringVarP(&nethAllocation, "nethAllocation", "", "pro-rata", "neth allocation strategy: pro-rata, sqrt, cap:<amount>, floor:<amount> or tiered:<points>=<mult>,...")
+	calcCmd.PersistentFlags().StringVarP(&rnethAllocation, "rnethAllocation", "", "pro-rata", "rneth allocation strategy: pro-rata, sqrt, cap:<amount>, floor:<amount> or tiered:<points>=<mult>,...")
+	calcCmd.PersistentFlags().StringVarP(&remainderRule, "remainder", "", string(allocation.RemainderLargest), "rounding remainder rule: largest-remainder or last")
 }
 
 var calcCmd = &cobra.Command{
@@ -42,78 +59,29 @@ var calcCmd = &cobra.Command{
 }
 
 func calcReward() error {
-	nethPoints, err := getPoints(nethPointsInputPath)
-	if err != nil {
-		return err
-	}
-
-	rnethPoints, err := getPoints(rnethPointsInputPath)
-	if err != nil {
-		return err
-	}
-
-	nethTotalAmount, isOk := big.NewInt(0).SetString(nethSsvRewardAmount, 10)
-	if !isOk {
-		return fmt.Errorf("amount parsing failed")
-	}
-
-	nethRewardInfo, err := distribute(nethPoints, nethTotalAmount)
-	if err != nil {
-		return err
-	}
-
-	if !check(nethRewardInfo, nethTotalAmount) {
-		return fmt.Errorf("neth reward check failed")
-	}
-
-	rnethTotalAmount, isOk := big.NewInt(0).SetString(rnethSsvRewardAmount, 10)
-	if !isOk {
-		return fmt.Errorf("amount parsing failed")
-	}
-
-	rnethRewardInfo, err := distribute(rnethPoints, rnethTotalAmount)
-	if err != nil {
-		return err
-	}
-
-	if !check(rnethRewardInfo, rnethTotalAmount) {
-		return fmt.Errorf("rneth reward check failed")
-	}
-
-	finalRewardInfo := map[common.Address]*big.Int{}
-	for key, value := range nethRewardInfo {
-		key2 := key
-		value2 := *value
-		finalRewardInfo[key2] = &value2
-	}
-
-	for key, value := range rnethRewardInfo {
-		v, ok := finalRewardInfo[key]
-		if ok {
-			finalRewardInfo[key] = big.NewInt(0).Add(value, v)
-		} else {
-			finalRewardInfo[key] = value
+	pools := make([]PoolReward, 0)
+	if poolsConfigPath != "" {
+		configPools, err := getPoolsConfig(poolsConfigPath)
+		if err != nil {
+			return err
 		}
+		pools = append(pools, configPools...)
 	}
-
-	if !check(finalRewardInfo, big.NewInt(0).Add(rnethTotalAmount, nethTotalAmount)) {
-		return fmt.Errorf("final reward check failed")
-	}
-
-	err = writeJson(nethRewardInfo, "neth", outputDir)
-	if err != nil {
-		return err
+	for _, poolFlag := range poolFlags {
+		pool, err := parsePoolFlag(poolFlag)
+		if err != nil {
+			return err
+		}
+		pools = append(pools, pool)
 	}
-	err = writeJson(rnethRewardInfo, "rneth", outputDir)
-	if err != nil {
-		return err
+	if nethPointsInputPath != "" {
+		pools = append(pools, PoolReward{Name: "neth", PointsPath: nethPointsInputPath, RewardAmount: nethSsvRewardAmount, Allocation: nethAllocation})
 	}
-	err = writeJson(finalRewardInfo, "final", outputDir)
-	if err != nil {
-		return err
+	if rnethPointsInputPath != "" {
+		pools = append(pools, PoolReward{Name: "rneth", PointsPath: rnethPointsInputPath, RewardAmount: rnethSsvRewardAmount, Allocation: rnethAllocation})
 	}
 
-	return nil
+	return calcPools(pools, rewardToken, finalName)
 }
 
 func check(rewards map[common.Address]*big.Int, totalAmount *big.Int) bool {
@@ -122,7 +90,7 @@ func check(rewards map[common.Address]*big.Int, totalAmount *big.Int) bool {
 		sum = big.NewInt(0).Add(sum, reward)
 	}
 
-	if !(big.NewInt(0).Sub(sum, totalAmount).Uint64() == 0) {
+	if sum.Cmp(totalAmount) != 0 {
 		log.Errorw("check", "sum", sum.String(), "totalAmount", totalAmount.String())
 		return false
 	}
@@ -131,14 +99,35 @@ func check(rewards map[common.Address]*big.Int, totalAmount *big.Int) bool {
 	return true
 }
 
-func writeJson(rewards map[common.Address]*big.Int, name, dir string) error {
+// mergeRewards adds up the rewards of every address across the given sets.
+func mergeRewards(rewardInfos ...map[common.Address]*big.Int) map[common.Address]*big.Int {
+	merged := map[common.Address]*big.Int{}
+	for _, rewardInfo := range rewardInfos {
+		for key, value := range rewardInfo {
+			if v, ok := merged[key]; ok {
+				merged[key] = big.NewInt(0).Add(v, value)
+			} else {
+				merged[key] = big.NewInt(0).Set(value)
+			}
+		}
+	}
+	return merged
+}
+
+func rewardsToStr(rewards map[common.Address]*big.Int) map[string]string {
 	rewardStr := map[string]string{}
 	for key, value := range rewards {
 		rewardStr[key.String()] = value.String()
 	}
+	return rewardStr
+}
 
+func writeRewards(rewardFile *RewardFile, name, dir string) error {
 	t := time.Now().Format("2006-01-02T15:04:05")
-	path := filepath.Join(dir, name+"-reward-"+t+".json")
+	return writeJsonFile(rewardFile, filepath.Join(dir, name+"-reward-"+t+".json"))
+}
+
+func writeJsonFile(v interface{}, path string) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w (path: %s)", err, path)
@@ -146,17 +135,26 @@ func writeJson(rewards map[common.Address]*big.Int, name, dir string) error {
 	defer f.Close()
 	enc := json.NewEncoder(f)
 	enc.SetIndent("", "  ")
-	if err := enc.Encode(rewardStr); err != nil {
-		return fmt.Errorf("failed to encode total rewards: %w", err)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
 	}
 
 	return nil
 }
 
-func distribute(points map[string]string, totalAmount *big.Int) (map[common.Address]*big.Int, error) {
-	pointInfo := map[common.Address]*big.Int{}
+func distribute(points map[string]string, unit string, totalAmount *big.Int, strategy, remainder string) (map[common.Address]*big.Int, error) {
+	allocator, err := allocation.ParseStrategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+	allocator = inPointUnit(allocator, unit)
+
+	rule, err := allocation.ParseRemainder(remainder)
+	if err != nil {
+		return nil, err
+	}
 
-	totalPoints := big.NewInt(0)
+	pointInfo := map[common.Address]*big.Int{}
 	for key, value := range points {
 		addr := common.HexToAddress(key)
 		point, isOk := big.NewInt(0).SetString(value, 10)
@@ -164,39 +162,48 @@ func distribute(points map[string]string, totalAmount *big.Int) (map[common.Addr
 			return nil, fmt.Errorf("amount parsing failed")
 		}
 		pointInfo[addr] = point
-		totalPoints = big.NewInt(0).Add(totalPoints, point)
-	}
-
-	length := len(pointInfo)
-	i := 0
-	totalAssigned := big.NewInt(0)
-	rewardInfo := map[common.Address]*big.Int{}
-	for addr, point := range pointInfo {
-		if i == length-1 {
-			reward := big.NewInt(0).Sub(totalAmount, totalAssigned)
-			rewardInfo[addr] = reward
-			break
-		}
-		reward := big.NewInt(0).Div(big.NewInt(0).Mul(totalAmount, point), totalPoints)
-		totalAssigned = big.NewInt(0).Add(totalAssigned, reward)
-		rewardInfo[addr] = reward
-		i++
 	}
 
+	return allocator.Allocate(pointInfo, totalAmount, rule)
+}
+
+// parseRewards parses a reward file content into amounts per address.
+func parseRewards(rewards map[string]string) (map[common.Address]*big.Int, error) {
+	rewardInfo := make(map[common.Address]*big.Int, len(rewards))
+	for key, value := range rewards {
+		amount, isOk := big.NewInt(0).SetString(value, 10)
+		if !isOk {
+			return nil, fmt.Errorf("amount parsing failed")
+		}
+		addr := common.HexToAddress(key)
+		if v, ok := rewardInfo[addr]; ok {
+			amount = big.NewInt(0).Add(v, amount)
+		}
+		rewardInfo[addr] = amount
+	}
 	return rewardInfo, nil
 }
 
+// getPoints reads an address → amount file, such as a reward file, with or
+// without envelope. Point files are read with getPointFile.
 func getPoints(filePath string) (map[string]string, error) {
+	pointStr, _, err := getPointFile(filePath)
+	return pointStr, err
+}
+
+// getPointFile reads a point file and returns its points normalized by
+// normalizePoints, with their unit. Bare files, as the point files of the past
+// rounds, are taken to be in gwei.
+func getPointFile(filePath string) (map[string]string, string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	points := make(map[string]string, 0)
-	err = json.Unmarshal(data, &points)
+	pointFile, err := parsePointFile(data)
 	if err != nil {
-		return nil, err
+		return nil, "", fmt.Errorf("failed to decode %s: %w", filePath, err)
 	}
-
-	return points, nil
+	pointStr, unit := normalizePoints(pointFile.Points, pointFile.Metadata.Unit)
+	return pointStr, unit, nil
 }
@@ -1,9 +1,8 @@
 package main
 
 import (
-	"fmt"
+	"github.com/bloxapp/ssv-rewards/allocation"
 	"github.com/spf13/cobra"
-	"math/big"
 )
 
 var (
@@ -13,7 +12,10 @@ var (
 func init() {
 	calcEigenCmd.PersistentFlags().StringVarP(&rnethPointsInputPath, "rnethPointsInputPath", "", "", "rneth points input file path")
 	calcEigenCmd.PersistentFlags().StringVarP(&rnethEigenRewardAmount, "rnethEigenRewardAmount", "", "", "ssv reward amount")
+	calcEigenCmd.PersistentFlags().Uint64VarP(&rewardRound, "round", "", 0, "reward round recorded in the reward files")
 	calcEigenCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+	calcEigenCmd.PersistentFlags().StringVarP(&rnethAllocation, "rnethAllocation", "", "pro-rata", "rneth allocation strategy: pro-rata, sqrt, cap:<amount>, floor:<amount> or tiered:<points>=<mult>,...")
+	calcEigenCmd.PersistentFlags().StringVarP(&remainderRule, "remainder", "", string(allocation.RemainderLargest), "rounding remainder rule: largest-remainder or last")
 }
 
 var calcEigenCmd = &cobra.Command{
@@ -31,29 +33,10 @@ var calcEigenCmd = &cobra.Command{
 }
 
 func calcEigenReward() error {
-	rnethPoints, err := getPoints(rnethPointsInputPath)
-	if err != nil {
-		return err
-	}
-
-	rnethEigenTotalAmount, isOk := big.NewInt(0).SetString(rnethEigenRewardAmount, 10)
-	if !isOk {
-		return fmt.Errorf("amount parsing failed")
-	}
-
-	rewardInfo, err := distribute(rnethPoints, rnethEigenTotalAmount)
-	if err != nil {
-		return err
-	}
-
-	if !check(rewardInfo, rnethEigenTotalAmount) {
-		return fmt.Errorf("eigen reward check failed")
-	}
-
-	err = writeJson(rewardInfo, "final-eigen", outputDir)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return calcPools([]PoolReward{{
+		Name:         "rneth-eigen",
+		PointsPath:   rnethPointsInputPath,
+		RewardAmount: rnethEigenRewardAmount,
+		Allocation:   rnethAllocation,
+	}}, "eigen", "final-eigen")
 }
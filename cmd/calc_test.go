@@ -1,11 +1,263 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/bloxapp/ssv-rewards/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
 
 func TestGetPoints(t *testing.T) {
-	points, err := getPoints("../data/neth-point.json")
+	points, err := getPoints("../data/input/neth-point-1.json")
 	if err != nil {
 		t.Fatal(err)
 	}
 	t.Log(points)
 }
+
+func TestDistributeDeterministic(t *testing.T) {
+	points, err := getPoints("../data/input/neth-point-1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	totalAmount, _ := big.NewInt(0).SetString("254000000000000000000", 10)
+	for _, remainder := range []string{"largest-remainder", "last"} {
+		var expected string
+		for i := 0; i < 20; i++ {
+			rewardInfo, err := distribute(points, defaultPointUnit, totalAmount, "pro-rata", remainder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !check(rewardInfo, totalAmount) {
+				t.Fatal("reward check failed")
+			}
+
+			data, err := json.Marshal(rewardsToStr(rewardInfo))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				expected = string(data)
+			} else if string(data) != expected {
+				t.Fatalf("%s: distribution differs between runs", remainder)
+			}
+		}
+	}
+}
+
+func TestParsePoolFlag(t *testing.T) {
+	pool, err := parsePoolFlag("neth:./data/neth-point.json:254:cap:10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Name != "neth" || pool.PointsPath != "./data/neth-point.json" || pool.RewardAmount != "254" || pool.Allocation != "cap:10" {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+
+	if _, err := parsePoolFlag("neth:./data/neth-point.json"); err == nil {
+		t.Fatal("expected error for missing amount")
+	}
+}
+
+func TestDistributePools(t *testing.T) {
+	pools, err := loadPools([]PoolReward{
+		{Name: "neth", PointsPath: "../data/input/neth-point-3.json", RewardAmount: "254000000000000000000"},
+		{Name: "rneth", PointsPath: "../data/input/rneth-point-3.json", RewardAmount: "556000000000000000000"},
+		{Name: "rneth-eigen", PointsPath: "../data/eigen/rneth-eigen-point-1.json", RewardAmount: "1000", Allocation: "sqrt"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewardInfos, finalRewardInfo, totalAmount, err := distributePools(pools, "largest-remainder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewardInfos) != 3 {
+		t.Fatalf("expected 3 pool rewards, got %d", len(rewardInfos))
+	}
+	if totalAmount.String() != "810000000000000001000" || !check(finalRewardInfo, totalAmount) {
+		t.Fatalf("unexpected total %s", totalAmount)
+	}
+}
+
+func TestRewardFile(t *testing.T) {
+	dir := t.TempDir()
+	input := "../data/input/neth-point-1.json"
+	rewardInfo := map[common.Address]*big.Int{
+		common.HexToAddress("0xf000000000000000000000000000000000000001"): big.NewInt(30),
+		common.HexToAddress("0x0a00000000000000000000000000000000000002"): big.NewInt(12),
+		common.HexToAddress("0xB000000000000000000000000000000000000003"): big.NewInt(0),
+	}
+	rewardFile, err := newRewardFile("ssv", 4, rewardInfo, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewardFile.TotalAmount != "42" || len(rewardFile.Inputs) != 1 || len(rewardFile.Inputs[0].SHA256) != 64 {
+		t.Fatalf("unexpected reward file %+v", rewardFile)
+	}
+
+	path := filepath.Join(dir, "reward.json")
+	if err := writeJsonFile(rewardFile, path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	addrs := []string{"0x0a00000000000000000000000000000000000002", "0xB000000000000000000000000000000000000003", "0xf000000000000000000000000000000000000001"}
+	for i := range addrs {
+		addrs[i] = common.HexToAddress(addrs[i]).Hex()
+	}
+	sort.Strings(addrs)
+	for i := 1; i < len(addrs); i++ {
+		if strings.Index(content, addrs[i-1]) > strings.Index(content, addrs[i]) {
+			t.Errorf("rewards not sorted by checksum address:\n%s", data)
+		}
+	}
+
+	rewards, err := getPoints(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parseRewards(rewards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for addr, amount := range rewardInfo {
+		if parsed[addr] == nil || parsed[addr].Cmp(amount) != 0 {
+			t.Errorf("reward mismatch for %s: got %v, want %s", addr, parsed[addr], amount)
+		}
+	}
+
+	rewardFile.TotalAmount = "43"
+	if err := writeJsonFile(rewardFile, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getPoints(path); err == nil {
+		t.Error("expected total amount mismatch error")
+	}
+	rewardFile.TotalAmount = "42"
+	rewardFile.SchemaVersion = rewardSchemaVersion + 1
+	if err := writeJsonFile(rewardFile, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getPoints(path); err == nil {
+		t.Error("expected newer schema version error")
+	}
+}
+
+func TestRewardFileMerkleOrder(t *testing.T) {
+	rewardInfo := map[common.Address]*big.Int{}
+	for i := 0; i < 32; i++ {
+		addr := common.BytesToAddress(crypto.Keccak256([]byte{byte(i)}))
+		rewardInfo[addr] = big.NewInt(int64(i + 1))
+	}
+	rewardFile, err := newRewardFile("ssv", 1, rewardInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(rewardFile.Rewards)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// build the leaves in the key order of the file, as merkle.ts does
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		t.Fatal(err)
+	}
+	leaves := make([]common.Hash, 0, len(rewardInfo))
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		amount, _ := new(big.Int).SetString(value.(string), 10)
+		leaves = append(leaves, merkle.LeafHash(common.HexToAddress(key.(string)), amount))
+	}
+	tree, err := merkle.NewTree(leaves)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	distribution, err := merkle.NewDistribution(rewardInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Root() != distribution.Root {
+		t.Errorf("root of the file key order %s, NewDistribution root %s", tree.Root().Hex(), distribution.Root.Hex())
+	}
+}
+
+func TestGetRewards(t *testing.T) {
+	dir := t.TempDir()
+	rewardInfo := map[common.Address]*big.Int{
+		common.HexToAddress("0xf000000000000000000000000000000000000001"): big.NewInt(30),
+	}
+	rewardFile, err := newRewardFile("eigen", 4, rewardInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "eigen-reward.json")
+	if err := writeJsonFile(rewardFile, path); err != nil {
+		t.Fatal(err)
+	}
+
+	rewards, round, err := getRewards(path, "eigen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round != 4 || len(rewards) != 1 {
+		t.Errorf("unexpected rewards %v of round %d", rewards, round)
+	}
+	if _, _, err := getRewards(path, "ssv"); err == nil {
+		t.Error("expected reward token mismatch error")
+	}
+	if _, round, err := getRewards("../data/total-final-reward-2024-10-22T12:39:05.json", "ssv"); err != nil || round != 0 {
+		t.Errorf("unexpected bare reward file round %d: %v", round, err)
+	}
+
+	defer func() {
+		total1PointsInputPath, total2PointsInputPath, outputDir = "", "", ""
+		rewardToken, rewardRound = "ssv", 0
+	}()
+	total1PointsInputPath, total2PointsInputPath, outputDir = path, path, dir
+	rewardToken, rewardRound = "ssv", 5
+	if err := sumReward(); err == nil {
+		t.Error("expected eigen rewards not to sum into ssv rewards")
+	}
+	rewardToken, rewardRound = "eigen", 4
+	if err := sumReward(); err == nil {
+		t.Error("expected previous rewards of the current round to be rejected")
+	}
+	rewardRound = 5
+	if err := sumReward(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	addr := common.HexToAddress("0xf000000000000000000000000000000000000001")
+	totalAmount := big.NewInt(100)
+	if !check(map[common.Address]*big.Int{addr: big.NewInt(100)}, totalAmount) {
+		t.Error("expected the exact total to pass")
+	}
+	off := new(big.Int).Add(totalAmount, new(big.Int).Lsh(big.NewInt(1), 64))
+	if check(map[common.Address]*big.Int{addr: off}, totalAmount) {
+		t.Error("expected a total off by 2^64 to fail")
+	}
+}
@@ -20,5 +20,11 @@ func main() {
 	rootCmd.AddCommand(calcCmd)
 	rootCmd.AddCommand(sumCmd)
 	rootCmd.AddCommand(calcEigenCmd)
+	rootCmd.AddCommand(scanCmd)
+	rootCmd.AddCommand(pointsCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(roundCmd)
+	rootCmd.AddCommand(merkleCmd)
+	rootCmd.AddCommand(verifyMerkleCmd)
 	_ = rootCmd.Execute()
 }
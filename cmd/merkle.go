@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/bloxapp/ssv-rewards/merkle"
+	"github.com/spf13/cobra"
+	"path/filepath"
+	"time"
+)
+
+var (
+	rewardInputPath string
+)
+
+func init() {
+	merkleCmd.PersistentFlags().StringVarP(&rewardInputPath, "rewardInputPath", "", "", "cumulative reward input file path")
+	merkleCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+}
+
+var merkleCmd = &cobra.Command{
+	Use:     "merkle",
+	Short:   "generate merkle tree",
+	Example: "./ssv-reward merkle -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := generateMerkle()
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		log.Info("merkle generation successful")
+	},
+}
+
+func generateMerkle() error {
+	rewards, err := getPoints(rewardInputPath)
+	if err != nil {
+		return err
+	}
+
+	rewardInfo, err := parseRewards(rewards)
+	if err != nil {
+		return err
+	}
+
+	distribution, err := merkle.NewDistribution(rewardInfo)
+	if err != nil {
+		return err
+	}
+
+	t := time.Now().Format("2006-01-02T15:04:05")
+	err = writeMerkle(distribution, filepath.Join(outputDir, "merkle-"+t+".json"))
+	if err != nil {
+		return err
+	}
+
+	log.Infow("merkle root", "root", distribution.Root.Hex(), "claims", len(distribution.Data))
+	return nil
+}
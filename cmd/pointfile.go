@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/allocation"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"strings"
+)
+
+// pointUnits are the units of the point files, by their power of ten in wei.
+var pointUnits = map[string]int64{
+	"wei":   0,
+	"gwei":  9,
+	"ether": 18,
+}
+
+// defaultPointUnit is the unit of the point files of the past rounds, without
+// metadata, and the unit of the tiered allocation thresholds.
+const defaultPointUnit = "gwei"
+
+// PointFile is a point file: the points of every address, as integers in the
+// unit of the metadata.
+type PointFile struct {
+	Metadata PointMetadata     `json:"metadata"`
+	Points   map[string]string `json:"points"`
+}
+
+// PointSourcesFile is the points of every address by source, like PointFile.
+type PointSourcesFile struct {
+	Metadata PointMetadata                `json:"metadata"`
+	Sources  map[string]map[string]string `json:"sources"`
+}
+
+// PointMetadata tells how the points of a point file were calculated.
+type PointMetadata struct {
+	Unit       string `json:"unit"`
+	Token      string `json:"token"`
+	StartBlock uint64 `json:"startBlock"`
+	EndBlock   uint64 `json:"endBlock"`
+	StartTime  uint64 `json:"startTime,omitempty"`
+	EndTime    uint64 `json:"endTime,omitempty"`
+	Accrual    string `json:"accrual"`
+	Formula    string `json:"formula"`
+	// FormulaVersion is the points.FormulaVersion of the calculation.
+	FormulaVersion int `json:"formulaVersion"`
+}
+
+// pointMetadata describes the points of token calculated with cfg, in unit.
+func pointMetadata(token, unit string, cfg points.Config) PointMetadata {
+	mode, _ := points.ParseMode(string(cfg.Mode))
+	formula, _ := points.ParseFormula(string(cfg.Formula))
+	meta := PointMetadata{
+		Unit:           unit,
+		Token:          token,
+		StartBlock:     cfg.StartBlock,
+		EndBlock:       cfg.EndBlock,
+		Accrual:        string(mode),
+		Formula:        string(formula),
+		FormulaVersion: points.FormulaVersion,
+	}
+	if mode == points.ModeSeconds {
+		meta.StartTime, meta.EndTime = cfg.StartTime, cfg.EndTime
+	}
+	return meta
+}
+
+// parsePointUnit checks a point unit, gwei if empty.
+func parsePointUnit(unit string) (string, error) {
+	if unit == "" {
+		return defaultPointUnit, nil
+	}
+	if _, ok := pointUnits[unit]; !ok {
+		return "", fmt.Errorf("unknown point unit %q", unit)
+	}
+	return unit, nil
+}
+
+// toUnit converts wei-based points to an integer in unit, rounding down.
+func toUnit(value *big.Int, unit string) *big.Int {
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(pointUnits[unit]), nil)
+	return new(big.Int).Quo(value, exp)
+}
+
+// pointsIn converts the cumulative balances to the integer strings stored in
+// the point files, keyed by lowercase address and dropping addresses that round
+// down to zero.
+func pointsIn(pointInfo map[common.Address]*big.Int, unit string) map[string]string {
+	pointStr := make(map[string]string)
+	for addr, point := range pointInfo {
+		value := toUnit(point, unit)
+		if value.Sign() == 0 {
+			continue
+		}
+		pointStr[strings.ToLower(addr.Hex())] = value.String()
+	}
+	return pointStr
+}
+
+// sourcesIn converts the points by source like pointsIn.
+func sourcesIn(sources map[common.Address]map[string]*big.Int, unit string) map[string]map[string]string {
+	sourceStr := make(map[string]map[string]string)
+	for addr, bySource := range sources {
+		pointStr := make(map[string]string)
+		for source, point := range bySource {
+			value := toUnit(point, unit)
+			if value.Sign() == 0 {
+				continue
+			}
+			pointStr[source] = value.String()
+		}
+		if len(pointStr) > 0 {
+			sourceStr[strings.ToLower(addr.Hex())] = pointStr
+		}
+	}
+	return sourceStr
+}
+
+// parsePointFile parses a point file with metadata, a RewardFile, or a bare
+// address → amount map such as a reward file or a point file of the past rounds.
+// Every amount must be a non-negative integer.
+func parsePointFile(data []byte) (*PointFile, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	pointFile := &PointFile{}
+	if _, ok := fields["schemaVersion"]; ok {
+		rewardFile, err := parseRewardFile(data)
+		if err != nil {
+			return nil, err
+		}
+		pointFile.Points = rewardsToStr(rewardFile.Rewards)
+	} else if _, ok := fields["metadata"]; ok {
+		if err := json.Unmarshal(data, pointFile); err != nil {
+			return nil, err
+		}
+		if _, ok := pointUnits[pointFile.Metadata.Unit]; !ok {
+			return nil, fmt.Errorf("unknown point unit %q", pointFile.Metadata.Unit)
+		}
+	} else if err := json.Unmarshal(data, &pointFile.Points); err != nil {
+		return nil, err
+	}
+
+	for addr, value := range pointFile.Points {
+		amount, ok := new(big.Int).SetString(value, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid amount %q of %s, not a non-negative integer", value, addr)
+		}
+	}
+	return pointFile, nil
+}
+
+// normalizePoints scales the points of a point file in a unit coarser than gwei
+// up to gwei, exactly, and returns them with their unit: gwei, or the finer unit
+// of the file, kept as is so that no address loses points.
+func normalizePoints(pointStr map[string]string, unit string) (map[string]string, string) {
+	if unit == "" || pointUnits[unit] <= pointUnits[defaultPointUnit] {
+		if unit == "" {
+			unit = defaultPointUnit
+		}
+		return pointStr, unit
+	}
+
+	normalized := make(map[string]string, len(pointStr))
+	exp := big.NewInt(pointUnits[unit] - pointUnits[defaultPointUnit])
+	scale := new(big.Int).Exp(big.NewInt(10), exp, nil)
+	for addr, value := range pointStr {
+		point, _ := new(big.Int).SetString(value, 10)
+		normalized[addr] = point.Mul(point, scale).String()
+	}
+	return normalized, defaultPointUnit
+}
+
+// inPointUnit states the tier thresholds of strategy, given in gwei points, in
+// unit, a unit no coarser than gwei as returned by normalizePoints.
+func inPointUnit(strategy allocation.Strategy, unit string) allocation.Strategy {
+	tiered, ok := strategy.(allocation.Tiered)
+	if !ok || unit == "" || unit == defaultPointUnit {
+		return strategy
+	}
+	exp := big.NewInt(pointUnits[defaultPointUnit] - pointUnits[unit])
+	return tiered.Scaled(new(big.Int).Exp(big.NewInt(10), exp, nil))
+}
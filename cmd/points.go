@@ -0,0 +1,464 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/allocation"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/bloxapp/ssv-rewards/scanner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	pointsToken        string
+	pointsPoolAddress  string
+	pointsTokenAddress string
+	pointsStartBlock   uint64
+	pointsEndBlock     uint64
+	eventsInputPath    string
+	accrualMode        string
+	pointsStartTime    uint64
+	pointsEndTime      uint64
+	rulesPath          string
+	lpAttribution      bool
+	bridgedPaths       []string
+	anomalyMode        string
+	snapshotPath       string
+	pointsFormula      string
+	sampleCount        int
+	sampleSeed         string
+	loyaltySpec        string
+	pointsUnit         string
+)
+
+// tokenPools maps the known LST names to their staking pool contracts.
+var tokenPools = map[string]common.Address{
+	"neth":  nethPoolV2,
+	"rneth": rnethPool,
+}
+
+func init() {
+	pointsCmd.PersistentFlags().StringVarP(&pointsToken, "token", "", "", "token name, e.g. neth or rneth")
+	pointsCmd.PersistentFlags().StringVarP(&pointsPoolAddress, "poolAddress", "", "", "pool contract address, defaults to the known pool of the token")
+	pointsCmd.PersistentFlags().StringVarP(&rulesPath, "rules", "", "", "address rules file path, the known pool, DEX and bridge if empty")
+	pointsCmd.PersistentFlags().Uint64VarP(&pointsStartBlock, "startBlock", "", 0, "round start block")
+	pointsCmd.PersistentFlags().Uint64VarP(&pointsEndBlock, "endBlock", "", 0, "round end block")
+	pointsCmd.PersistentFlags().BoolVarP(&lpAttribution, "lpAttribution", "", false, "attribute the balance of the DEX pairs with an lpToken rule to their LPs, read from the event cache")
+	pointsCmd.PersistentFlags().StringArrayVarP(&bridgedPaths, "bridged", "", nil, "bridged balance feed file path, repeatable")
+	pointsCmd.PersistentFlags().StringVarP(&snapshotPath, "snapshot", "", "", "balance snapshot file path, seeding the balances at its block instead of replaying the earlier events")
+	pointsCmd.PersistentFlags().StringVarP(&anomalyMode, "anomalies", "", "strict", "balance anomalies: strict fails, lenient clamps the balance and continues")
+	pointsCmd.PersistentFlags().StringVarP(&accrualMode, "accrual", "", string(points.ModeDays), "accrual mode: days (whole days of 7200 blocks), blocks or seconds")
+	pointsCmd.PersistentFlags().StringVarP(&pointsFormula, "formula", "", string(points.FormulaAccrued), "points formula: accrued, twab, samples or min-balance")
+	pointsCmd.PersistentFlags().IntVarP(&sampleCount, "samples", "", 0, "number of sample blocks drawn by the samples formula")
+	pointsCmd.PersistentFlags().StringVarP(&sampleSeed, "seed", "", "", "seed the sample blocks are drawn from")
+	pointsCmd.PersistentFlags().StringVarP(&loyaltySpec, "loyalty", "", "", "holding duration multipliers, <days>=<mult>,... e.g. 30=1.1,90=1.25")
+	pointsCmd.PersistentFlags().Uint64VarP(&pointsStartTime, "startTime", "", 0, "timestamp of the start block, required by the seconds accrual")
+	pointsCmd.PersistentFlags().Uint64VarP(&pointsEndTime, "endTime", "", 0, "timestamp of the end block, required by the seconds accrual")
+	pointsCmd.PersistentFlags().StringVarP(&pointsTokenAddress, "tokenAddress", "", "", "token contract address, defaults to the known address of the token")
+	pointsCmd.PersistentFlags().StringVarP(&eventsInputPath, "eventsInputPath", "", "", "transfer events input file path, read from the event cache if empty")
+	pointsCmd.PersistentFlags().StringVarP(&eventCacheDir, "cacheDir", "", "./data/cache", "event cache dir")
+	pointsCmd.PersistentFlags().StringVarP(&pointsUnit, "unit", "", defaultPointUnit, "point unit: wei, gwei or ether")
+	pointsCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+}
+
+var pointsCmd = &cobra.Command{
+	Use:     "points",
+	Short:   "calc staker points",
+	Example: "./ssv-reward points -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := calcPoints()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		log.Info("points calculation successful")
+	},
+}
+
+func calcPoints() error {
+	if pointsToken == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	pool, ok := tokenPools[pointsToken]
+	if pointsPoolAddress != "" {
+		if !common.IsHexAddress(pointsPoolAddress) {
+			return fmt.Errorf("invalid pool address: %s", pointsPoolAddress)
+		}
+		pool = common.HexToAddress(pointsPoolAddress)
+	} else if !ok && rulesPath == "" {
+		return fmt.Errorf("unknown token %s, poolAddress or rules is required", pointsToken)
+	}
+
+	rules, err := getRules(rulesPath, pointsToken, pool)
+	if err != nil {
+		return err
+	}
+	roles, err := rules.Roles()
+	if err != nil {
+		return err
+	}
+
+	token := tokenAddresses[pointsToken]
+	if pointsTokenAddress != "" {
+		if !common.IsHexAddress(pointsTokenAddress) {
+			return fmt.Errorf("invalid token address: %s", pointsTokenAddress)
+		}
+		token = common.HexToAddress(pointsTokenAddress)
+	}
+
+	mode, err := points.ParseMode(accrualMode)
+	if err != nil {
+		return err
+	}
+	lenient, err := parseAnomalyMode(anomalyMode)
+	if err != nil {
+		return err
+	}
+	formula, samples, err := getFormula(pointsFormula, sampleCount, sampleSeed, pointsStartBlock, pointsEndBlock)
+	if err != nil {
+		return err
+	}
+	loyalty, err := parseLoyalty(loyaltySpec)
+	if err != nil {
+		return err
+	}
+	unit, err := parsePointUnit(pointsUnit)
+	if err != nil {
+		return err
+	}
+
+	events, err := loadEvents(eventsInputPath, eventCacheDir, token, pointsEndBlock)
+	if err != nil {
+		return err
+	}
+	var lps []points.LP
+	if lpAttribution {
+		lps, err = getLPs(rules, eventCacheDir, pointsEndBlock)
+		if err != nil {
+			return err
+		}
+	}
+
+	bridged, err := getBridged(bridgedPaths)
+	if err != nil {
+		return err
+	}
+
+	cfg := points.Config{
+		StartBlock:   pointsStartBlock,
+		EndBlock:     pointsEndBlock,
+		Mode:         mode,
+		Formula:      formula,
+		SampleBlocks: samples,
+		StartTime:    pointsStartTime,
+		EndTime:      pointsEndTime,
+		Roles:        roles,
+		LPs:          lps,
+		Bridged:      bridged,
+		Loyalty:      loyalty,
+		Lenient:      lenient,
+	}
+	t := time.Now().Format("2006-01-02T15:04:05")
+	if snapshotPath != "" {
+		cfg.Snapshot, err = getSnapshot(snapshotPath, token)
+		if err != nil {
+			return err
+		}
+		err = checkSnapshot(events, cfg, filepath.Join(outputDir, pointsToken+"-snapshot-mismatches-"+t+".json"))
+		if err != nil {
+			return err
+		}
+	}
+
+	balance, anomalies, err := points.Accrue(events, cfg)
+	if len(anomalies) > 0 {
+		if err := writeAnomalies(anomalies, filepath.Join(outputDir, pointsToken+"-anomalies-"+t+".json")); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	sources, err := points.SourcesOf(balance, events, cfg)
+	if err != nil {
+		return err
+	}
+
+	err = writeBreakdowns(points.Breakdowns(balance, sources, cfg), filepath.Join(outputDir, pointsToken+"-point-breakdown-"+t))
+	if err != nil {
+		return err
+	}
+
+	meta := pointMetadata(pointsToken, unit, cfg)
+	if len(lps) > 0 || len(bridged) > 0 || len(loyalty) > 0 {
+		if err := writeSources(sources, meta, filepath.Join(outputDir, pointsToken+"-point-sources-"+t+".json")); err != nil {
+			return err
+		}
+	}
+	return writePoints(points.Sum(sources), meta, filepath.Join(outputDir, pointsToken+"-point-"+t+".json"))
+}
+
+// getRules reads the address rules of token from a rules file, or returns the
+// rules of the pool and the known DEX and bridge when rulesPath is empty.
+func getRules(rulesPath, token string, pool common.Address) (*points.Rules, error) {
+	if rulesPath == "" {
+		return &points.Rules{Token: token, Rules: []points.Rule{
+			{Address: pool, Role: string(points.RolePool)},
+			{Address: uniSwap, Role: string(points.RoleDex)},
+			{Address: zklink, Role: string(points.RoleBridge)},
+		}}, nil
+	}
+
+	rules, err := points.ReadRules(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	if rules.Token != "" && rules.Token != token {
+		return nil, fmt.Errorf("rules file %s is for token %s, not %s", rulesPath, rules.Token, token)
+	}
+	return rules, nil
+}
+
+// getLPs reads the LP share events of every DEX pair of the rules with an LP
+// token from the event cache.
+func getLPs(rules *points.Rules, cacheDir string, endBlock uint64) ([]points.LP, error) {
+	lpTokens := rules.LPTokens()
+	if len(lpTokens) == 0 {
+		return nil, fmt.Errorf("no lpToken in the %s rules", rules.Token)
+	}
+
+	lps := make([]points.LP, 0, len(lpTokens))
+	for pair, lpToken := range lpTokens {
+		events, err := loadEvents("", cacheDir, lpToken, endBlock)
+		if err != nil {
+			return nil, fmt.Errorf("LP token of %s: %w", pair, err)
+		}
+		lps = append(lps, points.LP{Pair: pair, Events: events})
+	}
+	return lps, nil
+}
+
+// getFormula parses the points formula and draws the sample blocks of the
+// samples formula.
+func getFormula(formula string, count int, seed string, startBlock, endBlock uint64) (points.Formula, []uint64, error) {
+	f, err := points.ParseFormula(formula)
+	if err != nil {
+		return "", nil, err
+	}
+	if f != points.FormulaSamples {
+		return f, nil, nil
+	}
+
+	samples, err := points.SampleBlocks(startBlock, endBlock, count, seed)
+	if err != nil {
+		return "", nil, err
+	}
+	log.Infow("sample blocks", "seed", seed, "blocks", samples)
+	return f, samples, nil
+}
+
+// parseLoyalty parses the loyalty multipliers, <days>=<mult>,... sorted by
+// days, none if spec is empty.
+func parseLoyalty(spec string) ([]points.LoyaltyTier, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	tiers := make([]points.LoyaltyTier, 0)
+	for _, tierSpec := range strings.Split(spec, ",") {
+		days, mult, ok := strings.Cut(tierSpec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid loyalty tier %q", tierSpec)
+		}
+		d, err := strconv.ParseUint(days, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loyalty days %q", days)
+		}
+		multiplier, err := allocation.ParseMultiplier(mult)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, points.LoyaltyTier{Days: d, Multiplier: multiplier})
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Days < tiers[j].Days
+	})
+	return tiers, nil
+}
+
+// parseAnomalyMode tells whether balance anomalies are lenient.
+func parseAnomalyMode(mode string) (bool, error) {
+	switch mode {
+	case "", "strict":
+		return false, nil
+	case "lenient":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown anomaly mode %q", mode)
+	}
+}
+
+// writeAnomalies writes the anomaly report and logs a summary.
+func writeAnomalies(anomalies []points.Anomaly, path string) error {
+	causes := map[string]int{}
+	for _, anomaly := range anomalies {
+		causes[anomaly.Cause]++
+	}
+	log.Warnw("balance anomalies", "count", len(anomalies), "causes", causes, "report", path)
+	return writeJsonFile(anomalies, path)
+}
+
+// getSnapshot reads the balance snapshot of token.
+func getSnapshot(path string, token common.Address) (*points.Snapshot, error) {
+	snapshot, err := points.ReadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Token != (common.Address{}) && token != (common.Address{}) && snapshot.Token != token {
+		return nil, fmt.Errorf("snapshot %s is for token %s, not %s", path, snapshot.Token, token)
+	}
+	return snapshot, nil
+}
+
+// checkSnapshot cross-checks the snapshot with the balances replayed from the
+// events before it, if any. The mismatches are written to path and fail the
+// points unless lenient.
+func checkSnapshot(events []points.TransferEvent, cfg points.Config, path string) error {
+	replayed := false
+	for _, event := range events {
+		if event.BlockNumber <= cfg.Snapshot.BlockNumber {
+			replayed = true
+			break
+		}
+	}
+	if !replayed {
+		log.Infow("no events before the snapshot, not cross-checked", "block", cfg.Snapshot.BlockNumber)
+		return nil
+	}
+
+	mismatches := points.CheckSnapshot(events, cfg, cfg.Snapshot)
+	if len(mismatches) == 0 {
+		log.Infow("snapshot matches the replayed balances", "block", cfg.Snapshot.BlockNumber)
+		return nil
+	}
+	log.Warnw("snapshot mismatches", "count", len(mismatches), "report", path)
+	if err := writeJsonFile(mismatches, path); err != nil {
+		return err
+	}
+	if !cfg.Lenient {
+		return fmt.Errorf("%d balances differ from the snapshot at block %d", len(mismatches), cfg.Snapshot.BlockNumber)
+	}
+	return nil
+}
+
+// writeBreakdowns writes the point breakdowns to path.json, and their balance
+// changes to path.csv, one row per change.
+func writeBreakdowns(breakdowns []points.Breakdown, path string) error {
+	if err := writeJsonFile(breakdowns, path+".json"); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path + ".csv")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w (path: %s)", err, path+".csv")
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	err = w.Write([]string{"address", "blockNumber", "txHash", "logIndex", "amount", "balance", "elapsed", "points"})
+	if err != nil {
+		return err
+	}
+	for _, breakdown := range breakdowns {
+		for _, change := range breakdown.Changes {
+			err = w.Write([]string{
+				strings.ToLower(breakdown.Address.Hex()),
+				strconv.FormatUint(change.BlockNumber, 10),
+				change.TxHash.Hex(),
+				strconv.FormatUint(uint64(change.LogIndex), 10),
+				change.Amount.String(),
+				change.Balance.String(),
+				strconv.FormatUint(change.Elapsed, 10),
+				change.Points.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// getBridged reads the bridged balance feeds.
+func getBridged(paths []string) ([]points.Bridged, error) {
+	bridged := make([]points.Bridged, 0, len(paths))
+	for _, path := range paths {
+		feed, err := points.ReadBridged(path)
+		if err != nil {
+			return nil, err
+		}
+		bridged = append(bridged, *feed)
+	}
+	return bridged, nil
+}
+
+// loadEvents reads the events of token from eventsPath, or from the event
+// cache when eventsPath is empty. The cache must cover the window up to endBlock.
+func loadEvents(eventsPath, cacheDir string, token common.Address, endBlock uint64) ([]points.TransferEvent, error) {
+	if eventsPath != "" {
+		return getEvents(eventsPath)
+	}
+
+	cache, err := scanner.NewCache(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	events, meta, err := cache.Load(token)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil || meta.EndBlock < endBlock {
+		return nil, fmt.Errorf("event cache of %s does not cover endBlock %d, scan first", token, endBlock)
+	}
+	return events, nil
+}
+
+// getEvents reads a JSON array of events, or a JSONL file such as the cache.
+func getEvents(filePath string) ([]points.TransferEvent, error) {
+	if filepath.Ext(filePath) == ".jsonl" {
+		return scanner.ReadEvents(filePath)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]points.TransferEvent, 0)
+	err = json.Unmarshal(data, &events)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func writeSources(sources map[common.Address]map[string]*big.Int, meta PointMetadata, path string) error {
+	sourcesFile := PointSourcesFile{Metadata: meta, Sources: sourcesIn(sources, meta.Unit)}
+	return writeJsonFile(sourcesFile, path)
+}
+
+func writePoints(pointInfo map[common.Address]*big.Int, meta PointMetadata, path string) error {
+	pointFile := PointFile{Metadata: meta, Points: pointsIn(pointInfo, meta.Unit)}
+	return writeJsonFile(pointFile, path)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"github.com/bloxapp/ssv-rewards/points"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLoyalty(t *testing.T) {
+	tiers, err := parseLoyalty("90=1.25,30=1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []points.LoyaltyTier{{Days: 30, Multiplier: 11000}, {Days: 90, Multiplier: 12500}}
+	if !reflect.DeepEqual(tiers, expected) {
+		t.Fatalf("unexpected tiers %+v", tiers)
+	}
+
+	if _, err := parseLoyalty("30"); err == nil {
+		t.Fatal("expected error for missing multiplier")
+	}
+}
+
+func TestGetPointsUnits(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		content  string
+		expected map[string]string
+		unit     string
+	}{
+		{`{"0xa": "1500000000", "0xb": "1"}`, map[string]string{"0xa": "1500000000", "0xb": "1"}, "gwei"},
+		{`{"metadata": {"unit": "gwei"}, "points": {"0xa": "15"}}`, map[string]string{"0xa": "15"}, "gwei"},
+		{`{"metadata": {"unit": "wei"}, "points": {"0xa": "1500000000", "0xb": "1"}}`, map[string]string{"0xa": "1500000000", "0xb": "1"}, "wei"},
+		{`{"metadata": {"unit": "ether"}, "points": {"0xa": "2"}}`, map[string]string{"0xa": "2000000000"}, "gwei"},
+	}
+	for i, test := range tests {
+		pointStr, unit, err := getPointFile(write("points.json", test.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(pointStr, test.expected) || unit != test.unit {
+			t.Errorf("%d: unexpected points %v in %s", i, pointStr, unit)
+		}
+	}
+
+	// tier thresholds are in gwei points whatever the unit of the file
+	gwei := map[string]string{"0xa": "10", "0xb": "30", "0xc": "1"}
+	wei := map[string]string{"0xa": "10000000000", "0xb": "30000000000", "0xc": "1000000000"}
+	gweiRewards, err := distribute(gwei, "gwei", big.NewInt(1000), "tiered:20=2", "largest-remainder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weiRewards, err := distribute(wei, "wei", big.NewInt(1000), "tiered:20=2", "largest-remainder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gweiRewards, weiRewards) {
+		t.Errorf("tiered rewards depend on the point unit: %v in gwei, %v in wei", gweiRewards, weiRewards)
+	}
+
+	for _, content := range []string{
+		`{"0xa": "1.5"}`,
+		`{"0xa": "-1"}`,
+		`{"0xa": 15}`,
+		`{"metadata": {"unit": "finney"}, "points": {"0xa": "15"}}`,
+	} {
+		if _, err := getPoints(write("points.json", content)); err == nil {
+			t.Errorf("expected error for %s", content)
+		}
+	}
+}
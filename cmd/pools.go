@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PoolReward is one pool whose points share a reward amount.
+type PoolReward struct {
+	Name         string `json:"name"`
+	PointsPath   string `json:"pointsPath"`
+	RewardAmount string `json:"rewardAmount"`
+	// Allocation is the allocation strategy spec, pro-rata by default.
+	Allocation string `json:"allocation"`
+}
+
+// parsePoolFlag parses name:pointsPath:amount[:allocation].
+func parsePoolFlag(s string) (PoolReward, error) {
+	parts := strings.SplitN(s, ":", 4)
+	if len(parts) < 3 {
+		return PoolReward{}, fmt.Errorf("invalid pool %q, expected name:pointsPath:amount[:allocation]", s)
+	}
+
+	pool := PoolReward{Name: parts[0], PointsPath: parts[1], RewardAmount: parts[2]}
+	if len(parts) == 4 {
+		pool.Allocation = parts[3]
+	}
+	return pool, nil
+}
+
+// getPoolsConfig reads a JSON list of pools. Relative points paths are
+// resolved against the directory of the config file.
+func getPoolsConfig(filePath string) ([]PoolReward, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]PoolReward, 0)
+	err = json.Unmarshal(data, &pools)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pools {
+		if !filepath.IsAbs(pools[i].PointsPath) {
+			pools[i].PointsPath = filepath.Join(filepath.Dir(filePath), pools[i].PointsPath)
+		}
+	}
+	return pools, nil
+}
+
+// rewardPool is a pool with its points loaded.
+type rewardPool struct {
+	Name   string
+	Points map[string]string
+	// Unit is the unit of Points, gwei or finer.
+	Unit        string
+	TotalAmount *big.Int
+	Allocation  string
+}
+
+func loadPools(pools []PoolReward) ([]rewardPool, error) {
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("no pools")
+	}
+
+	names := map[string]bool{}
+	loaded := make([]rewardPool, 0, len(pools))
+	for _, pool := range pools {
+		if pool.Name == "" {
+			return nil, fmt.Errorf("pool name is required")
+		}
+		if names[pool.Name] {
+			return nil, fmt.Errorf("duplicate pool %s", pool.Name)
+		}
+		names[pool.Name] = true
+
+		points, unit, err := getPointFile(pool.PointsPath)
+		if err != nil {
+			return nil, err
+		}
+
+		totalAmount, isOk := big.NewInt(0).SetString(pool.RewardAmount, 10)
+		if !isOk {
+			return nil, fmt.Errorf("%s amount parsing failed", pool.Name)
+		}
+
+		loaded = append(loaded, rewardPool{
+			Name:        pool.Name,
+			Points:      points,
+			Unit:        unit,
+			TotalAmount: totalAmount,
+			Allocation:  pool.Allocation,
+		})
+	}
+	return loaded, nil
+}
+
+// distributePools distributes the reward of every pool and merges them,
+// checking each pool and the merged total against the exact amounts.
+func distributePools(pools []rewardPool, remainder string) ([]map[common.Address]*big.Int, map[common.Address]*big.Int, *big.Int, error) {
+	totalAmount := big.NewInt(0)
+	rewardInfos := make([]map[common.Address]*big.Int, 0, len(pools))
+	for _, pool := range pools {
+		rewardInfo, err := distribute(pool.Points, pool.Unit, pool.TotalAmount, pool.Allocation, remainder)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: %w", pool.Name, err)
+		}
+
+		if !check(rewardInfo, pool.TotalAmount) {
+			return nil, nil, nil, fmt.Errorf("%s reward check failed", pool.Name)
+		}
+
+		totalAmount = big.NewInt(0).Add(totalAmount, pool.TotalAmount)
+		rewardInfos = append(rewardInfos, rewardInfo)
+	}
+
+	finalRewardInfo := mergeRewards(rewardInfos...)
+	if !check(finalRewardInfo, totalAmount) {
+		return nil, nil, nil, fmt.Errorf("final reward check failed")
+	}
+
+	return rewardInfos, finalRewardInfo, totalAmount, nil
+}
+
+// calcPools writes one reward file of token per pool and the merged finalName
+// file.
+func calcPools(pools []PoolReward, token, finalName string) error {
+	loaded, err := loadPools(pools)
+	if err != nil {
+		return err
+	}
+
+	rewardInfos, finalRewardInfo, _, err := distributePools(loaded, remainderRule)
+	if err != nil {
+		return err
+	}
+
+	allocations := make(map[string]string, len(pools))
+	pointsPaths := make([]string, 0, len(pools))
+	for i, pool := range pools {
+		rewardFile, err := newRewardFile(token, rewardRound, rewardInfos[i], pool.PointsPath)
+		if err != nil {
+			return err
+		}
+		rewardFile.Allocations = map[string]string{pool.Name: allocationSpec(pool.Allocation)}
+		rewardFile.Remainder = remainderRule
+		err = writeRewards(rewardFile, pool.Name, outputDir)
+		if err != nil {
+			return err
+		}
+		allocations[pool.Name] = allocationSpec(pool.Allocation)
+		pointsPaths = append(pointsPaths, pool.PointsPath)
+	}
+
+	rewardFile, err := newRewardFile(token, rewardRound, finalRewardInfo, pointsPaths...)
+	if err != nil {
+		return err
+	}
+	rewardFile.Allocations = allocations
+	rewardFile.Remainder = remainderRule
+	return writeRewards(rewardFile, finalName, outputDir)
+}
+
+// allocationSpec returns the allocation strategy spec, pro-rata if empty.
+func allocationSpec(spec string) string {
+	if spec == "" {
+		return "pro-rata"
+	}
+	return spec
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"os"
+	"runtime/debug"
+	"sort"
+)
+
+// rewardSchemaVersion is the version of the RewardFile format, bumped on every
+// incompatible change.
+const rewardSchemaVersion = 1
+
+// version is the tool version, set with -ldflags "-X main.version=v1.2.3".
+// The module version and VCS revision of the build are used if empty.
+var version string
+
+// RewardFile is a reward file: the rewards of every address, with what they
+// were calculated from.
+type RewardFile struct {
+	SchemaVersion int `json:"schemaVersion"`
+	// Round is the reward round, zero if unknown.
+	Round uint64 `json:"round,omitempty"`
+	// Token is the reward token, e.g. ssv or eigen.
+	Token       string `json:"token"`
+	TotalAmount string `json:"totalAmount"`
+	// Allocations are the allocation strategies by pool, and Remainder the
+	// rounding remainder rule, for distributed rewards.
+	Allocations map[string]string `json:"allocations,omitempty"`
+	Remainder   string            `json:"remainder,omitempty"`
+	// Inputs are the point and reward files the rewards were calculated from.
+	Inputs      []RewardInput `json:"inputs"`
+	ToolVersion string        `json:"toolVersion"`
+	Rewards     Rewards       `json:"rewards"`
+}
+
+// RewardInput is an input file and the SHA-256 of its content.
+type RewardInput struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Rewards are reward amounts by address, encoded as a JSON object of checksum
+// addresses to decimal strings, sorted by checksum address like the leaves of
+// merkle.NewDistribution.
+type Rewards map[common.Address]*big.Int
+
+func (r Rewards) MarshalJSON() ([]byte, error) {
+	addrs := make([]common.Address, 0, len(r))
+	for addr := range r {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Hex() < addrs[j].Hex()
+	})
+
+	buf := bytes.NewBufferString("{")
+	for i, addr := range addrs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(buf, "%q:%q", addr.Hex(), r[addr].String())
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (r *Rewards) UnmarshalJSON(data []byte) error {
+	rewardStr := map[string]string{}
+	if err := json.Unmarshal(data, &rewardStr); err != nil {
+		return err
+	}
+	rewardInfo, err := parseRewards(rewardStr)
+	if err != nil {
+		return err
+	}
+	*r = rewardInfo
+	return nil
+}
+
+// newRewardFile wraps the rewards of token, reading the inputs to hash them.
+func newRewardFile(token string, round uint64, rewardInfo map[common.Address]*big.Int, inputs ...string) (*RewardFile, error) {
+	totalAmount := big.NewInt(0)
+	for _, amount := range rewardInfo {
+		totalAmount = big.NewInt(0).Add(totalAmount, amount)
+	}
+
+	rewardFile := &RewardFile{
+		SchemaVersion: rewardSchemaVersion,
+		Round:         round,
+		Token:         token,
+		TotalAmount:   totalAmount.String(),
+		Inputs:        make([]RewardInput, 0, len(inputs)),
+		ToolVersion:   toolVersion(),
+		Rewards:       rewardInfo,
+	}
+	for _, path := range inputs {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		rewardFile.Inputs = append(rewardFile.Inputs, RewardInput{Path: path, SHA256: hex.EncodeToString(sum[:])})
+	}
+	return rewardFile, nil
+}
+
+// parseRewardFile parses a reward file, checking its schema version and total.
+func parseRewardFile(data []byte) (*RewardFile, error) {
+	rewardFile := &RewardFile{}
+	if err := json.Unmarshal(data, rewardFile); err != nil {
+		return nil, err
+	}
+	if rewardFile.SchemaVersion > rewardSchemaVersion {
+		return nil, fmt.Errorf("reward schema version %d is newer than %d, update the tool", rewardFile.SchemaVersion, rewardSchemaVersion)
+	}
+
+	totalAmount := big.NewInt(0)
+	for _, amount := range rewardFile.Rewards {
+		totalAmount = big.NewInt(0).Add(totalAmount, amount)
+	}
+	if totalAmount.String() != rewardFile.TotalAmount {
+		return nil, fmt.Errorf("rewards add up to %s, not the total amount %s", totalAmount, rewardFile.TotalAmount)
+	}
+	return rewardFile, nil
+}
+
+// getRewards reads a reward file, with or without envelope, and returns its
+// rewards and round, zero if unknown. The rewards of an envelope must be of
+// token.
+func getRewards(filePath, token string) (map[common.Address]*big.Int, uint64, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode %s: %w", filePath, err)
+	}
+	if _, ok := fields["schemaVersion"]; !ok {
+		rewards, err := getPoints(filePath)
+		if err != nil {
+			return nil, 0, err
+		}
+		rewardInfo, err := parseRewards(rewards)
+		return rewardInfo, 0, err
+	}
+
+	rewardFile, err := parseRewardFile(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode %s: %w", filePath, err)
+	}
+	if rewardFile.Token != token {
+		return nil, 0, fmt.Errorf("%s holds %s rewards, not %s", filePath, rewardFile.Token, token)
+	}
+	return rewardFile.Rewards, rewardFile.Round, nil
+}
+
+// toolVersion returns the version, or the module version and VCS revision of
+// the build.
+func toolVersion() string {
+	if version != "" {
+		return version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	v := info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			v += "+" + setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				v += "-dirty"
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,601 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/allocation"
+	"github.com/bloxapp/ssv-rewards/merkle"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/bloxapp/ssv-rewards/scanner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+var (
+	roundManifestPath string
+)
+
+func init() {
+	roundCmd.PersistentFlags().StringVarP(&roundManifestPath, "manifest", "", "", "round manifest file path")
+	roundCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+	roundCmd.PersistentFlags().StringVarP(&rpcHost, "rpc", "", "", "node rpc url, required to resolve the window dates")
+}
+
+var roundCmd = &cobra.Command{
+	Use:     "round",
+	Short:   "run a reward round from transfer events to merkle proofs",
+	Example: "./ssv-reward round -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := runRound()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		log.Info("round successful")
+	},
+}
+
+// RoundManifest describes everything a reward round needs. Relative paths are
+// resolved against the directory of the manifest file.
+type RoundManifest struct {
+	Round      uint64 `json:"round"`
+	StartBlock uint64 `json:"startBlock"`
+	EndBlock   uint64 `json:"endBlock"`
+	// StartDate and EndDate set the window instead of the blocks, resolved to
+	// the first block at or after each date.
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	// Accrual is the points accrual mode, days by default.
+	Accrual string `json:"accrual"`
+	// Formula is the points formula, accrued by default. The samples formula
+	// draws Samples blocks of the window from Seed.
+	Formula string `json:"formula"`
+	Samples int    `json:"samples"`
+	Seed    string `json:"seed"`
+	// Loyalty are the holding duration multipliers, <days>=<mult>,... none by
+	// default.
+	Loyalty string `json:"loyalty"`
+	// Unit is the unit of the point files, gwei by default.
+	Unit string `json:"unit"`
+	// StartTime and EndTime are the timestamps of the window blocks, required
+	// by the seconds accrual.
+	StartTime uint64 `json:"startTime"`
+	EndTime   uint64 `json:"endTime"`
+	// CacheDir is the event cache read for pools without an eventsPath.
+	CacheDir string `json:"cacheDir"`
+	// Anomalies is the balance anomaly mode, strict or lenient, strict by
+	// default.
+	Anomalies string `json:"anomalies"`
+	// Remainder is the rounding remainder rule, largest-remainder by default.
+	Remainder string        `json:"remainder"`
+	Pools     []RoundPool   `json:"pools"`
+	Rewards   []RoundReward `json:"rewards"`
+}
+
+// RoundPool is one staking pool whose stakers earn points in the round.
+type RoundPool struct {
+	Token        string `json:"token"`
+	TokenAddress string `json:"tokenAddress"`
+	PoolAddress  string `json:"poolAddress"`
+	// RulesPath is the address rules file, the known pool, DEX and bridge if
+	// empty.
+	RulesPath string `json:"rulesPath"`
+	// LPAttribution attributes the balance of the DEX pairs with an lpToken
+	// rule to their LPs, read from the event cache.
+	LPAttribution bool `json:"lpAttribution"`
+	// BridgedPaths are the bridged balance feed files.
+	BridgedPaths []string `json:"bridgedPaths"`
+	// SnapshotPath is the balance snapshot seeding the balances, at or before
+	// the start block.
+	SnapshotPath string `json:"snapshotPath"`
+	// EventsPath is the transfer events file, read from the event cache if
+	// empty.
+	EventsPath string `json:"eventsPath"`
+}
+
+// RoundReward is one reward token distributed in the round, with its own
+// budgets, cumulative file and merkle tree.
+type RoundReward struct {
+	Token string `json:"token"`
+	// PreviousTotalPath is the cumulative reward file of the previous round,
+	// empty for the first round.
+	PreviousTotalPath string        `json:"previousTotalPath"`
+	Budgets           []RoundBudget `json:"budgets"`
+}
+
+// RoundBudget is the amount of a reward token given to the stakers of a pool.
+type RoundBudget struct {
+	Pool   string `json:"pool"`
+	Amount string `json:"amount"`
+	// Allocation is the allocation strategy spec, pro-rata by default.
+	Allocation string `json:"allocation"`
+}
+
+func getRoundManifest(filePath string) (*RoundManifest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &RoundManifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(filePath)
+	if manifest.CacheDir != "" && !filepath.IsAbs(manifest.CacheDir) {
+		manifest.CacheDir = filepath.Join(dir, manifest.CacheDir)
+	}
+	for i := range manifest.Pools {
+		if manifest.Pools[i].EventsPath != "" && !filepath.IsAbs(manifest.Pools[i].EventsPath) {
+			manifest.Pools[i].EventsPath = filepath.Join(dir, manifest.Pools[i].EventsPath)
+		}
+		if manifest.Pools[i].RulesPath != "" && !filepath.IsAbs(manifest.Pools[i].RulesPath) {
+			manifest.Pools[i].RulesPath = filepath.Join(dir, manifest.Pools[i].RulesPath)
+		}
+		if manifest.Pools[i].SnapshotPath != "" && !filepath.IsAbs(manifest.Pools[i].SnapshotPath) {
+			manifest.Pools[i].SnapshotPath = filepath.Join(dir, manifest.Pools[i].SnapshotPath)
+		}
+		for j, path := range manifest.Pools[i].BridgedPaths {
+			if !filepath.IsAbs(path) {
+				manifest.Pools[i].BridgedPaths[j] = filepath.Join(dir, path)
+			}
+		}
+	}
+	for i := range manifest.Rewards {
+		path := manifest.Rewards[i].PreviousTotalPath
+		if path != "" && !filepath.IsAbs(path) {
+			manifest.Rewards[i].PreviousTotalPath = filepath.Join(dir, path)
+		}
+	}
+
+	if manifest.Remainder == "" {
+		manifest.Remainder = string(allocation.RemainderLargest)
+	}
+
+	return manifest, manifest.validate()
+}
+
+func (m *RoundManifest) validate() error {
+	if m.Round == 0 {
+		return fmt.Errorf("round is required")
+	}
+	if m.StartDate.IsZero() != m.EndDate.IsZero() {
+		return fmt.Errorf("startDate and endDate go together")
+	}
+	if m.hasDates() {
+		if m.StartBlock != 0 || m.EndBlock != 0 {
+			return fmt.Errorf("the window is set by either blocks or dates")
+		}
+		if !m.EndDate.After(m.StartDate) {
+			return fmt.Errorf("invalid window: startDate %s, endDate %s", m.StartDate, m.EndDate)
+		}
+	} else if m.EndBlock <= m.StartBlock {
+		return fmt.Errorf("invalid window: startBlock %d, endBlock %d", m.StartBlock, m.EndBlock)
+	}
+	mode, err := points.ParseMode(m.Accrual)
+	if err != nil {
+		return err
+	}
+	if mode == points.ModeSeconds && !m.hasDates() && m.EndTime <= m.StartTime {
+		return fmt.Errorf("invalid window: startTime %d, endTime %d", m.StartTime, m.EndTime)
+	}
+	formula, err := points.ParseFormula(m.Formula)
+	if err != nil {
+		return err
+	}
+	if formula == points.FormulaSamples && (m.Samples <= 0 || m.Seed == "") {
+		return fmt.Errorf("the samples formula needs samples and a seed")
+	}
+	if _, err := parseLoyalty(m.Loyalty); err != nil {
+		return err
+	}
+	if _, err := parsePointUnit(m.Unit); err != nil {
+		return err
+	}
+	if _, err := parseAnomalyMode(m.Anomalies); err != nil {
+		return err
+	}
+	if _, err := allocation.ParseRemainder(m.Remainder); err != nil {
+		return err
+	}
+	if len(m.Pools) == 0 {
+		return fmt.Errorf("no pools in round %d", m.Round)
+	}
+	if len(m.Rewards) == 0 {
+		return fmt.Errorf("no rewards in round %d", m.Round)
+	}
+
+	pools := map[string]bool{}
+	for _, pool := range m.Pools {
+		if pool.Token == "" {
+			return fmt.Errorf("pool token is required")
+		}
+		if pools[pool.Token] {
+			return fmt.Errorf("duplicate pool token %s", pool.Token)
+		}
+		pools[pool.Token] = true
+		if _, ok := tokenPools[pool.Token]; !ok && pool.PoolAddress == "" && pool.RulesPath == "" {
+			return fmt.Errorf("unknown token %s, poolAddress or rulesPath is required", pool.Token)
+		}
+		if pool.PoolAddress != "" && !common.IsHexAddress(pool.PoolAddress) {
+			return fmt.Errorf("invalid pool address: %s", pool.PoolAddress)
+		}
+		if pool.EventsPath == "" {
+			if m.CacheDir == "" {
+				return fmt.Errorf("%s: eventsPath or cacheDir is required", pool.Token)
+			}
+			if _, ok := tokenAddresses[pool.Token]; !ok && pool.TokenAddress == "" {
+				return fmt.Errorf("unknown token %s, tokenAddress is required", pool.Token)
+			}
+		}
+		if pool.LPAttribution && m.CacheDir == "" {
+			return fmt.Errorf("%s: lpAttribution reads the LP events from cacheDir", pool.Token)
+		}
+		if pool.TokenAddress != "" && !common.IsHexAddress(pool.TokenAddress) {
+			return fmt.Errorf("invalid token address: %s", pool.TokenAddress)
+		}
+	}
+
+	rewards := map[string]bool{}
+	for _, reward := range m.Rewards {
+		if reward.Token == "" {
+			return fmt.Errorf("reward token is required")
+		}
+		if rewards[reward.Token] {
+			return fmt.Errorf("duplicate reward token %s", reward.Token)
+		}
+		rewards[reward.Token] = true
+		if len(reward.Budgets) == 0 {
+			return fmt.Errorf("no budgets for reward token %s", reward.Token)
+		}
+
+		budgets := map[string]bool{}
+		for _, budget := range reward.Budgets {
+			if !pools[budget.Pool] {
+				return fmt.Errorf("%s budget for unknown pool %s", reward.Token, budget.Pool)
+			}
+			if budgets[budget.Pool] {
+				return fmt.Errorf("duplicate %s budget for pool %s", reward.Token, budget.Pool)
+			}
+			budgets[budget.Pool] = true
+			if _, err := allocation.ParseStrategy(budget.Allocation); err != nil {
+				return fmt.Errorf("%s %s: %w", reward.Token, budget.Pool, err)
+			}
+			if _, ok := big.NewInt(0).SetString(budget.Amount, 10); !ok {
+				return fmt.Errorf("invalid %s amount for %s: %q", reward.Token, budget.Pool, budget.Amount)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RoundManifest) hasDates() bool {
+	return !m.StartDate.IsZero()
+}
+
+// resolveWindow sets the window blocks, and their timestamps, from the dates.
+func (m *RoundManifest) resolveWindow(ctx context.Context, finder *scanner.BlockFinder) error {
+	start, err := finder.BlockAt(ctx, m.StartDate)
+	if err != nil {
+		return fmt.Errorf("startDate: %w", err)
+	}
+	end, err := finder.BlockAt(ctx, m.EndDate)
+	if err != nil {
+		return fmt.Errorf("endDate: %w", err)
+	}
+
+	m.StartBlock, m.StartTime = uint64(start.Number), uint64(start.Timestamp)
+	m.EndBlock, m.EndTime = uint64(end.Number), uint64(end.Timestamp)
+	log.Infow("round window", "startDate", m.StartDate, "startBlock", m.StartBlock, "endDate", m.EndDate, "endBlock", m.EndBlock)
+	return nil
+}
+
+func (p RoundPool) token() common.Address {
+	if p.TokenAddress != "" {
+		return common.HexToAddress(p.TokenAddress)
+	}
+	return tokenAddresses[p.Token]
+}
+
+func (p RoundPool) pool() common.Address {
+	if p.PoolAddress != "" {
+		return common.HexToAddress(p.PoolAddress)
+	}
+	return tokenPools[p.Token]
+}
+
+func runRound() error {
+	manifest, err := getRoundManifest(roundManifestPath)
+	if err != nil {
+		return err
+	}
+
+	if manifest.hasDates() {
+		// without rpc only the blocks resolved by earlier runs are known
+		var client scanner.Client
+		if rpcHost != "" {
+			ethClient, cancel, err := GetEthClient(rpcHost)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			client = ethClient
+		}
+
+		blocksPath := ""
+		if manifest.CacheDir != "" {
+			blocksPath = filepath.Join(manifest.CacheDir, "blocks.json")
+		}
+		finder, err := scanner.NewBlockFinder(client, blocksPath)
+		if err != nil {
+			return err
+		}
+		if err := manifest.resolveWindow(context.Background(), finder); err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Join(outputDir, "round-"+strconv.FormatUint(manifest.Round, 10))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return calcRound(manifest, dir)
+}
+
+// RoundEarning is what an address earned of one reward token.
+type RoundEarning struct {
+	Round      string `json:"round"`
+	Cumulative string `json:"cumulative"`
+}
+
+// calcRound computes the points of every pool, then the rewards, cumulative
+// totals and merkle proofs of every reward token, and writes them to dir.
+func calcRound(manifest *RoundManifest, dir string) error {
+	formula, samples, err := getFormula(manifest.Formula, manifest.Samples, manifest.Seed, manifest.StartBlock, manifest.EndBlock)
+	if err != nil {
+		return err
+	}
+
+	unit, err := parsePointUnit(manifest.Unit)
+	if err != nil {
+		return err
+	}
+
+	poolPoints := make(map[string]map[string]string, len(manifest.Pools))
+	pointsPaths := make(map[string]string, len(manifest.Pools))
+	for _, pool := range manifest.Pools {
+		events, err := loadEvents(pool.EventsPath, manifest.CacheDir, pool.token(), manifest.EndBlock)
+		if err != nil {
+			return err
+		}
+		rules, err := getRules(pool.RulesPath, pool.Token, pool.pool())
+		if err != nil {
+			return err
+		}
+		roles, err := rules.Roles()
+		if err != nil {
+			return err
+		}
+		var lps []points.LP
+		if pool.LPAttribution {
+			lps, err = getLPs(rules, manifest.CacheDir, manifest.EndBlock)
+			if err != nil {
+				return err
+			}
+		}
+
+		bridged, err := getBridged(pool.BridgedPaths)
+		if err != nil {
+			return err
+		}
+
+		lenient, _ := parseAnomalyMode(manifest.Anomalies)
+		loyalty, _ := parseLoyalty(manifest.Loyalty)
+		cfg := points.Config{
+			StartBlock:   manifest.StartBlock,
+			EndBlock:     manifest.EndBlock,
+			Mode:         points.Mode(manifest.Accrual),
+			Formula:      formula,
+			SampleBlocks: samples,
+			StartTime:    manifest.StartTime,
+			EndTime:      manifest.EndTime,
+			Roles:        roles,
+			LPs:          lps,
+			Bridged:      bridged,
+			Loyalty:      loyalty,
+			Lenient:      lenient,
+		}
+		if pool.SnapshotPath != "" {
+			cfg.Snapshot, err = getSnapshot(pool.SnapshotPath, pool.token())
+			if err != nil {
+				return err
+			}
+			err = checkSnapshot(events, cfg, filepath.Join(dir, pool.Token+"-snapshot-mismatches.json"))
+			if err != nil {
+				return fmt.Errorf("%s: %w", pool.Token, err)
+			}
+		}
+
+		balance, anomalies, err := points.Accrue(events, cfg)
+		if len(anomalies) > 0 {
+			if err := writeAnomalies(anomalies, filepath.Join(dir, pool.Token+"-anomalies.json")); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s points: %w", pool.Token, err)
+		}
+		sources, err := points.SourcesOf(balance, events, cfg)
+		if err != nil {
+			return fmt.Errorf("%s points: %w", pool.Token, err)
+		}
+
+		err = writeBreakdowns(points.Breakdowns(balance, sources, cfg), filepath.Join(dir, pool.Token+"-point-breakdown"))
+		if err != nil {
+			return err
+		}
+
+		meta := pointMetadata(pool.Token, unit, cfg)
+		if len(lps) > 0 || len(bridged) > 0 || len(loyalty) > 0 {
+			sourcesFile := PointSourcesFile{Metadata: meta, Sources: sourcesIn(sources, unit)}
+			err = writeJsonFile(sourcesFile, filepath.Join(dir, pool.Token+"-point-sources.json"))
+			if err != nil {
+				return err
+			}
+		}
+		pointInfo := points.Sum(sources)
+		pointFile := PointFile{Metadata: meta, Points: pointsIn(pointInfo, unit)}
+		pointsPaths[pool.Token] = filepath.Join(dir, pool.Token+"-point.json")
+		err = writeJsonFile(pointFile, pointsPaths[pool.Token])
+		if err != nil {
+			return err
+		}
+		// the rewards are allocated from the exact points, not rounded to unit
+		poolPoints[pool.Token] = pointsIn(pointInfo, "wei")
+	}
+
+	summary := map[string]map[string]RoundEarning{}
+	for _, reward := range manifest.Rewards {
+		rewardDir := filepath.Join(dir, reward.Token)
+		if err := os.MkdirAll(rewardDir, 0755); err != nil {
+			return err
+		}
+
+		finalRewardInfo, totalRewardInfo, err := calcRoundReward(manifest, reward, poolPoints, pointsPaths, rewardDir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", reward.Token, err)
+		}
+
+		for addr, cumulative := range totalRewardInfo {
+			earning := RoundEarning{Round: "0", Cumulative: cumulative.String()}
+			if amount, ok := finalRewardInfo[addr]; ok {
+				earning.Round = amount.String()
+			}
+			if _, ok := summary[addr.Hex()]; !ok {
+				summary[addr.Hex()] = map[string]RoundEarning{}
+			}
+			summary[addr.Hex()][reward.Token] = earning
+		}
+	}
+
+	return writeJsonFile(summary, filepath.Join(dir, "summary.json"))
+}
+
+// calcRoundReward distributes one reward token between the pools, adds the
+// previous cumulative rewards and builds the merkle tree. poolPoints are the
+// exact points of every pool, in wei. It returns the round and cumulative
+// rewards.
+func calcRoundReward(manifest *RoundManifest, reward RoundReward, poolPoints map[string]map[string]string, pointsPaths map[string]string, dir string) (map[common.Address]*big.Int, map[common.Address]*big.Int, error) {
+	pools := make([]rewardPool, 0, len(reward.Budgets))
+	for _, budget := range reward.Budgets {
+		totalAmount, _ := big.NewInt(0).SetString(budget.Amount, 10)
+		pools = append(pools, rewardPool{
+			Name:        budget.Pool,
+			Points:      poolPoints[budget.Pool],
+			Unit:        "wei",
+			TotalAmount: totalAmount,
+			Allocation:  budget.Allocation,
+		})
+	}
+
+	rewardInfos, finalRewardInfo, roundTotalAmount, err := distributePools(pools, manifest.Remainder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allocations := make(map[string]string, len(reward.Budgets))
+	inputs := make([]string, 0, len(reward.Budgets))
+	for i, budget := range reward.Budgets {
+		rewardFile, err := newRewardFile(reward.Token, manifest.Round, rewardInfos[i], pointsPaths[budget.Pool])
+		if err != nil {
+			return nil, nil, err
+		}
+		rewardFile.Allocations = map[string]string{budget.Pool: allocationSpec(budget.Allocation)}
+		rewardFile.Remainder = manifest.Remainder
+		err = writeJsonFile(rewardFile, filepath.Join(dir, budget.Pool+"-reward.json"))
+		if err != nil {
+			return nil, nil, err
+		}
+		allocations[budget.Pool] = allocationSpec(budget.Allocation)
+		inputs = append(inputs, pointsPaths[budget.Pool])
+	}
+
+	previousRewardInfo := map[common.Address]*big.Int{}
+	if reward.PreviousTotalPath != "" {
+		previous, round, err := getRewards(reward.PreviousTotalPath, reward.Token)
+		if err != nil {
+			return nil, nil, err
+		}
+		if round >= manifest.Round {
+			return nil, nil, fmt.Errorf("%s is of round %d, not before round %d", reward.PreviousTotalPath, round, manifest.Round)
+		}
+		previousRewardInfo = previous
+	}
+
+	totalRewardInfo := mergeRewards(previousRewardInfo, finalRewardInfo)
+	previousTotalAmount := big.NewInt(0)
+	for _, amount := range previousRewardInfo {
+		previousTotalAmount = big.NewInt(0).Add(previousTotalAmount, amount)
+	}
+	if !check(totalRewardInfo, big.NewInt(0).Add(previousTotalAmount, roundTotalAmount)) {
+		return nil, nil, fmt.Errorf("total reward check failed")
+	}
+
+	distribution, err := merkle.NewDistribution(totalRewardInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finalFile, err := newRewardFile(reward.Token, manifest.Round, finalRewardInfo, inputs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	finalFile.Allocations = allocations
+	finalFile.Remainder = manifest.Remainder
+	err = writeJsonFile(finalFile, filepath.Join(dir, "final-reward.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalInputs := []string{filepath.Join(dir, "final-reward.json")}
+	if reward.PreviousTotalPath != "" {
+		totalInputs = append(totalInputs, reward.PreviousTotalPath)
+	}
+	totalFile, err := newRewardFile(reward.Token, manifest.Round, totalRewardInfo, totalInputs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = writeJsonFile(totalFile, filepath.Join(dir, "total-final-reward.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = writeMerkle(distribution, filepath.Join(dir, "merkle.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Infow("round reward finished", "round", manifest.Round, "reward", reward.Token, "root", distribution.Root.Hex(), "dir", dir)
+	return finalRewardInfo, totalRewardInfo, nil
+}
+
+// writeMerkle writes the distribution in the compact form we publish.
+func writeMerkle(distribution *merkle.Distribution, path string) error {
+	data, err := json.Marshal(distribution)
+	if err != nil {
+		return fmt.Errorf("failed to encode merkle: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w (path: %s)", err, path)
+	}
+	return nil
+}
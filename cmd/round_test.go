@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/bloxapp/ssv-rewards/merkle"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/ethereum/go-ethereum/common"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCalcRound(t *testing.T) {
+	manifest := &RoundManifest{
+		Round:      2,
+		StartBlock: 20207950,
+		EndBlock:   20866890,
+		Remainder:  "largest-remainder",
+		Pools: []RoundPool{
+			{Token: "neth", EventsPath: "../data/events/neth-transfer-events.json"},
+			{Token: "rneth", EventsPath: "../data/events/rneth-transfer-events.json"},
+		},
+		Rewards: []RoundReward{
+			{
+				Token:             "ssv",
+				PreviousTotalPath: "../data/final-reward-2024-07-29T11:00:49.json",
+				Budgets: []RoundBudget{
+					{Pool: "neth", Amount: "254000000000000000000"},
+					{Pool: "rneth", Amount: "556000000000000000000"},
+				},
+			},
+			{
+				Token: "eigen",
+				Budgets: []RoundBudget{
+					{Pool: "rneth", Amount: "1000000000000000000000", Allocation: "sqrt"},
+				},
+			},
+		},
+	}
+	if err := manifest.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := calcRound(manifest, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := map[string]map[string]RoundEarning{}
+	data, err := os.ReadFile(filepath.Join(dir, "summary.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, reward := range manifest.Rewards {
+		total, err := getPoints(filepath.Join(dir, reward.Token, "total-final-reward.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		totalRewardInfo, err := parseRewards(total)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		distribution, err := getDistribution(filepath.Join(dir, reward.Token, "merkle.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mismatches := merkle.Check(distribution, totalRewardInfo); len(mismatches) != 0 {
+			t.Fatalf("%s: unexpected mismatches %v", reward.Token, mismatches)
+		}
+
+		for addr, amount := range totalRewardInfo {
+			if summary[addr.Hex()][reward.Token].Cumulative != amount.String() {
+				t.Errorf("%s: summary mismatch for %s", reward.Token, addr)
+			}
+		}
+	}
+
+	if _, ok := summary[common.HexToAddress("0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa").Hex()]["eigen"]; !ok {
+		t.Error("missing eigen earnings in summary")
+	}
+
+	pointStr, err := getPoints(filepath.Join(dir, "neth-point.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdowns := make([]points.Breakdown, 0)
+	data, err = os.ReadFile(filepath.Join(dir, "neth-point-breakdown.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &breakdowns); err != nil {
+		t.Fatal(err)
+	}
+	if len(breakdowns) == 0 {
+		t.Fatal("empty breakdown")
+	}
+	for _, breakdown := range breakdowns {
+		gwei := toUnit(breakdown.Points, defaultPointUnit).String()
+		if gwei != "0" && pointStr[strings.ToLower(breakdown.Address.Hex())] != gwei {
+			t.Errorf("breakdown of %s does not match its points", breakdown.Address)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "neth-point-breakdown.csv")); err != nil {
+		t.Error(err)
+	}
+
+	// the rewards do not depend on the unit of the point files
+	manifest.Unit = "ether"
+	etherDir := t.TempDir()
+	if err := calcRound(manifest, etherDir); err != nil {
+		t.Fatal(err)
+	}
+	for _, reward := range manifest.Rewards {
+		expected, err := getPoints(filepath.Join(dir, reward.Token, "final-reward.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := getPoints(filepath.Join(etherDir, reward.Token, "final-reward.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s rewards differ with the point unit", reward.Token)
+		}
+	}
+}
@@ -3,14 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
-	"github.com/ethereum/go-ethereum"
+	"github.com/bloxapp/ssv-rewards/scanner"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"math/big"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
-var zeroAddr = common.HexToAddress("0x0000000000000000000000000000000000000000")
-
 var nethToken = common.HexToAddress("0xC6572019548dfeBA782bA5a2093C836626C7789A")
 var nethPoolV2 = common.HexToAddress("0xf3C79408164abFB6fD5dDfE33B084E4ad2C07c18")
 
@@ -22,81 +23,116 @@ var (
 	nethStartBlock  uint64 = 16683911 // 17979259
 )
 
-func GetEthClient(rpcHost string) (*ethclient.Client, func(), error) {
-	if rpcHost == "" {
-		return nil, nil, fmt.Errorf("config.yaml is missing 'ethrpc'")
-	}
-
-	client, err := ethclient.Dial(rpcHost)
-	if err != nil {
-		return nil, nil, err
-	}
+// tokenAddresses maps the known LST names to their token contracts.
+var tokenAddresses = map[string]common.Address{
+	"neth":  nethToken,
+	"rneth": rnethToken,
+}
 
-	return client, func() {
-		client.Close()
-	}, nil
+// tokenStartBlocks maps the known LST names to their deployment blocks.
+var tokenStartBlocks = map[string]uint64{
+	"neth":  nethStartBlock,
+	"rneth": rnethStartBlock,
 }
 
-var TransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+var (
+	rpcHost       string
+	scanToken     string
+	scanAddress   string
+	scanStart     uint64
+	eventCacheDir string
+	scanRetries   int
+	scanBackoff   time.Duration
+	scanWorkers   int
+	scanRPS       float64
+	scanConfirms  uint64
+	scanFinalized bool
+)
 
-type TransferEvent struct {
-	BlockNumber uint64
-	From        common.Address
-	To          common.Address
-	Amount      *big.Int
+func init() {
+	scanCmd.PersistentFlags().StringVarP(&rpcHost, "rpc", "", "", "archive node rpc url")
+	scanCmd.PersistentFlags().StringVarP(&scanToken, "token", "", "", "token name, e.g. neth or rneth")
+	scanCmd.PersistentFlags().StringVarP(&scanAddress, "tokenAddress", "", "", "token contract address, e.g. an LP token, instead of a known token name")
+	scanCmd.PersistentFlags().Uint64VarP(&scanStart, "startBlock", "", 0, "scan start block, defaults to the token deployment block")
+	scanCmd.PersistentFlags().StringVarP(&eventCacheDir, "cacheDir", "", "./data/cache", "event cache dir")
+	scanCmd.PersistentFlags().IntVarP(&scanRetries, "retries", "", scanner.DefaultRetries, "retries per failed log request")
+	scanCmd.PersistentFlags().DurationVarP(&scanBackoff, "backoff", "", scanner.DefaultBackoff, "initial retry backoff, doubled on every retry")
+	scanCmd.PersistentFlags().IntVarP(&scanWorkers, "concurrency", "", scanner.DefaultConcurrency, "number of block windows fetched in parallel")
+	scanCmd.PersistentFlags().Float64VarP(&scanRPS, "rps", "", 0, "max log requests per second, 0 for no limit")
+	scanCmd.PersistentFlags().Uint64VarP(&scanConfirms, "confirmations", "", scanner.DefaultConfirmations, "blocks kept between the scan and the chain head")
+	scanCmd.PersistentFlags().BoolVarP(&scanFinalized, "finalized", "", false, "scan up to the finalized block instead of head - confirmations")
 }
 
-func ScanTokenInfo(startBlock uint64, rpcHost string, tokenAddr common.Address) ([]TransferEvent, error) {
-	eth1Client, cancel, err := GetEthClient(rpcHost)
-	if err != nil {
-		return nil, err
-	}
-	defer cancel()
+var scanCmd = &cobra.Command{
+	Use:     "scan",
+	Short:   "scan transfer events into the event cache",
+	Example: "./ssv-reward scan -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := scanEvents()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		log.Info("scan successful")
+	},
+}
 
-	curBlock, err := eth1Client.BlockNumber(context.Background())
-	if err != nil {
-		return nil, err
+func scanEvents() error {
+	token, ok := tokenAddresses[scanToken]
+	if scanAddress != "" {
+		if !common.IsHexAddress(scanAddress) {
+			return fmt.Errorf("invalid token address: %s", scanAddress)
+		}
+		token = common.HexToAddress(scanAddress)
+	} else if !ok {
+		return fmt.Errorf("unknown token %q", scanToken)
 	}
-
-	transferEvents := make([]TransferEvent, 0)
-	for fromBlock := startBlock; fromBlock < curBlock; {
-		nextBlock := fromBlock + 20000
-		if nextBlock >= curBlock {
-			nextBlock = curBlock
+	startBlock := scanStart
+	if startBlock == 0 {
+		startBlock, ok = tokenStartBlocks[scanToken]
+		if !ok {
+			return fmt.Errorf("startBlock is required for token %s", token)
 		}
+	}
 
-		filter := ethereum.FilterQuery{
-			FromBlock: big.NewInt(int64(fromBlock)),
-			ToBlock:   big.NewInt(int64(nextBlock)),
-			Addresses: []common.Address{tokenAddr},
-			Topics:    [][]common.Hash{{TransferTopic}},
-		}
+	cache, err := scanner.NewCache(eventCacheDir)
+	if err != nil {
+		return err
+	}
 
-		log.Infow("scan block", "fromBlock", fromBlock, "nextBlock", nextBlock)
+	eth1Client, cancel, err := GetEthClient(rpcHost)
+	if err != nil {
+		return err
+	}
+	defer cancel()
 
-		fromBlock = nextBlock
+	// stop on SIGINT, keeping what was scanned so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := scanner.New(eth1Client, cache)
+	s.Retries = scanRetries
+	s.Backoff = scanBackoff
+	s.Concurrency = scanWorkers
+	s.RPS = scanRPS
+	s.Confirmations = scanConfirms
+	s.Finalized = scanFinalized
+	return s.Scan(ctx, token, startBlock)
+}
 
-		addLogs, err := eth1Client.FilterLogs(context.Background(), filter)
-		if err != nil {
-			continue
-		}
+func GetEthClient(rpcHost string) (*scanner.RPCClient, func(), error) {
+	if rpcHost == "" {
+		return nil, nil, fmt.Errorf("rpc is required")
+	}
 
-		for _, l := range addLogs {
-			var from common.Address
-			copy(from[:], l.Topics[1][12:])
-			var to common.Address
-			copy(to[:], l.Topics[2][12:])
-			amount := big.NewInt(0).SetBytes(l.Data)
-			transferEvents = append(transferEvents, TransferEvent{
-				BlockNumber: l.BlockNumber,
-				From:        from,
-				To:          to,
-				Amount:      amount,
-			})
-		}
+	client, err := scanner.Dial(rpcHost)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return transferEvents, nil
+	return client, func() {
+		client.Close()
+	}, nil
 }
 
 var uniSwap = common.HexToAddress("0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83")
@@ -1,340 +1,70 @@
 package main
 
 import (
-	"encoding/json"
+	"github.com/bloxapp/ssv-rewards/points"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/params"
-	logging "github.com/ipfs/go-log/v2"
-	"math/big"
 	"testing"
 )
 
-type BalanceInfo struct {
-	Balance           *big.Int
-	BlockNumber       uint64
-	CumulativeBalance *big.Int
-	isCalcEnd         bool
-}
-
-// ssv
-var startBlock uint64 = 20207950
-var endBlock uint64 = 20866890
-
-// eigen
-//var startBlock uint64 = 19516980 // 3.26
-//var endBlock uint64 = 21010000   // 10.20
-
-func TestNethStakerBalance(t *testing.T) {
-	_ = logging.SetLogLevel("*", "INFO")
-
-	//events, err := ScanTokenInfo(uint64(nethStartBlock), "https://api.chainup.net/ethereum-archive/mainnet/37dc27cef7a444c682e8f6266580a588", nethToken)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-	//
-	//for _, event := range events {
-	//	t.Log(event)
-	//}
-	//data, err := json.Marshal(events)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-	//fmt.Println(string(data))
-
-	//d := "[{\"BlockNumber\":16696696,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xef76d4e75154739f75f6068b3470c7968cc3fcd1\",\"Amount\":100000000000000000},{\"BlockNumber\":16697199,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16712762,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":2000000000000000000},{\"BlockNumber\":16738180,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16742506,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":32000000000000000000},{\"BlockNumber\":16752852,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xbb673b6a571c68e734d17aca9dd28ed6518deb5b\",\"Amount\":40000000000000000},{\"BlockNumber\":16797724,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":32000000000000000000},{\"BlockNumber\":16804644,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x16cb658ad7d1bcdc3ed82edb7a3bbf62010e92fe\",\"Amount\":10000000000000000},{\"BlockNumber\":16868561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":766000000000000000000},{\"BlockNumber\":16894330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"Amount\":1000000000000000000},{\"BlockNumber\":16894378,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":500000000000000000},{\"BlockNumber\":16894380,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"Amount\":1086206863302042},{\"BlockNumber\":16894388,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":474748794029138918},{\"BlockNumber\":16894405,\"From\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1086206863302042},{\"BlockNumber\":16922990,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"Amount\":9993157647393183},{\"BlockNumber\":16923061,\"From\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":9993157647393183},{\"BlockNumber\":16988058,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":9984433062832875},{\"BlockNumber\":17051688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":29936427888275051239},{\"BlockNumber\":17057861,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd98673532035b029d4a3a0db26481781c5373e6f\",\"Amount\":9978809296091683746},{\"BlockNumber\":17129073,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"Amount\":99635257935296669},{\"BlockNumber\":17129082,\"From\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":17135448,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2692246865295780245673},{\"BlockNumber\":17170922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":16041276527582763801},{\"BlockNumber\":17179269,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":30000000000000000000},{\"BlockNumber\":17199736,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"Amount\":15932122553750811079},{\"BlockNumber\":17215932,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"Amount\":2001202182403918126},{\"BlockNumber\":17233805,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"Amount\":4479437575836008436},{\"BlockNumber\":17241326,\"From\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4479430000000000000},{\"BlockNumber\":17270942,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":32842572116743766140},{\"BlockNumber\":17290950,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":1523660899754596108200},{\"BlockNumber\":17291076,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":31936420000000000000},{\"BlockNumber\":17333785,\"From\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":15932120000000000000},{\"BlockNumber\":17341573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771406230365327},{\"BlockNumber\":17398313,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2979011328614270682},{\"BlockNumber\":17425808,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000},{\"BlockNumber\":17432573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720913477045793},{\"BlockNumber\":17434794,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"Amount\":99275408142455514},{\"BlockNumber\":17484564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"Amount\":9922316967587324},{\"BlockNumber\":17489121,\"From\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9920000000000000},{\"BlockNumber\":17518915,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"Amount\":991956246248792912},{\"BlockNumber\":17533564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd664cebc8e0032919d7a7c8e01d676f6fad02f50\",\"Amount\":494522969307008037},{\"BlockNumber\":17571319,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x5d799e0a223fd22618a06286dc48a4796f325f5a\",\"Amount\":25701487236170348},{\"BlockNumber\":17597848,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"Amount\":98812870372368696},{\"BlockNumber\":17605658,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"Amount\":49406435186184348},{\"BlockNumber\":17605731,\"From\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":991950000000000000},{\"BlockNumber\":17617688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3062621338913464424},{\"BlockNumber\":17668205,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"Amount\":9871958646351160817},{\"BlockNumber\":17755337,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"Amount\":55202741790957015},{\"BlockNumber\":17768176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"Amount\":2562505583144619516},{\"BlockNumber\":17807538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"Amount\":4924935454536839130},{\"BlockNumber\":17809224,\"From\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9871950000000000000},{\"BlockNumber\":17839858,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"Amount\":983024677252183256},{\"BlockNumber\":17917449,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2946252706668955110},{\"BlockNumber\":17979156,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1000000000000000000},{\"BlockNumber\":17979183,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"Amount\":121260756771124243},{\"BlockNumber\":17979192,\"From\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":121260756771124243},{\"BlockNumber\":17979259,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1001200000000000000},{\"BlockNumber\":18162476,\"From\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":29980000000000000},{\"BlockNumber\":18176933,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":33842572116743766140},{\"BlockNumber\":18177023,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":32787771406230365327},{\"BlockNumber\":18177028,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":23329720913477045793},{\"BlockNumber\":18190333,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2934904865339960985},{\"BlockNumber\":18234913,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2933775493550301839175},{\"BlockNumber\":18255686,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3630ab305635199133315097b31099a44ee13497\",\"Amount\":146611070809985799},{\"BlockNumber\":18309439,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"Amount\":97615395041254911},{\"BlockNumber\":18340838,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa57c927df5b836d203560a5b568af75831939eed\",\"Amount\":9758395522519076},{\"BlockNumber\":18353006,\"From\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97610000000000000},{\"BlockNumber\":18410620,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6c660815908bdf2ce8e6907be71aceab742b16a0\",\"Amount\":9748301710359976},{\"BlockNumber\":18411198,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":1934063059335419394091},{\"BlockNumber\":18411361,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"Amount\":10333199812981575391},{\"BlockNumber\":18428065,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771400000000000},{\"BlockNumber\":18428073,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32787770000000000000},{\"BlockNumber\":18434967,\"From\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10333190000000000000},{\"BlockNumber\":18447117,\"From\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":55200000000000000},{\"BlockNumber\":18488309,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":4904806536605318186928},{\"BlockNumber\":18549529,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2919036432784638382},{\"BlockNumber\":18568176,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720910000000000},{\"BlockNumber\":18568611,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":23329720000000000000},{\"BlockNumber\":18568634,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":33842572126451177260},{\"BlockNumber\":18568646,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":33842570000000000000},{\"BlockNumber\":18632085,\"From\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":983020000000000000},{\"BlockNumber\":18880981,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":90000000000000000000},{\"BlockNumber\":18881087,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881093,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881105,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":818000000000000000000},{\"BlockNumber\":18961465,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"Amount\":12453570089929040},{\"BlockNumber\":18961583,\"From\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":12453570089929040},{\"BlockNumber\":18998925,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd91a4319bd678a590edfe54b3269724723d5d8e5\",\"Amount\":918476238821690554},{\"BlockNumber\":19032559,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3865594373350226542},{\"BlockNumber\":19110776,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x624b54d7007aa7dc90fdbf9e7ca424710a23b2d5\",\"Amount\":125279769118651925},{\"BlockNumber\":19127919,\"From\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":99270000000000000},{\"BlockNumber\":19152293,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":174613448052857980717},{\"BlockNumber\":19191894,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":241041491744028613766},{\"BlockNumber\":19230922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":5783036497886009192},{\"BlockNumber\":19261042,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":313083035805725396427},{\"BlockNumber\":19284103,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":481521267382216291},{\"BlockNumber\":19288561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":5007821180775049432},{\"BlockNumber\":19325360,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97000000000000000000},{\"BlockNumber\":19330097,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":97100730794736286139},{\"BlockNumber\":19338760,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":92389911865460853823},{\"BlockNumber\":19340671,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000000},{\"BlockNumber\":19341568,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":54786130000000000000},{\"BlockNumber\":19359798,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":121836363182737794},{\"BlockNumber\":19387771,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32000000000000000000},{\"BlockNumber\":19388776,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"Amount\":118901199549875},{\"BlockNumber\":19388785,\"From\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000},{\"BlockNumber\":19437462,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9610157615933493},{\"BlockNumber\":19437489,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":105711733775268429},{\"BlockNumber\":19437549,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":19445444,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49000000000000000000},{\"BlockNumber\":19488988,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"Amount\":5000000000000000000},{\"BlockNumber\":19489057,\"From\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5000000000000000000},{\"BlockNumber\":19490020,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":153529040000000000000},{\"BlockNumber\":19501310,\"From\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":98810000000000000},{\"BlockNumber\":19516331,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":73055630000000000000},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"Amount\":28518061848502766},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"Amount\":949219533396014760},{\"BlockNumber\":19523287,\"From\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":28518061848502764},{\"BlockNumber\":19523323,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":94921953339601476},{\"BlockNumber\":19523336,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":854297580056413284},{\"BlockNumber\":19537830,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":95975018848244923},{\"BlockNumber\":19537835,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":19538291,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9597442141483715},{\"BlockNumber\":19573557,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":121836363182737794},{\"BlockNumber\":19610245,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19610259,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19614913,\"From\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4924935454536839130},{\"BlockNumber\":19620820,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xf719ae6b7ca7be6b95e09a0bee44e9ae6fbc5b35\",\"Amount\":95867553568404505},{\"BlockNumber\":19667605,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9580702825451906},{\"BlockNumber\":19673513,\"From\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9580702825451906},{\"BlockNumber\":19724787,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19724798,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19736649,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000000},{\"BlockNumber\":19737170,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4997000000000000000000},{\"BlockNumber\":19737911,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":96000000000000000000},{\"BlockNumber\":19745241,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":957066890856151182},{\"BlockNumber\":19745680,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":957066890000000000},{\"BlockNumber\":19751176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":95699019687904983},{\"BlockNumber\":19755185,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x36fc96ab32d3a7192cbaf5fa40aba4e9dbac22de\",\"Amount\":9569383940971178},{\"BlockNumber\":19771279,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"Amount\":17750179354765280},{\"BlockNumber\":19831097,\"From\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"To\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"Amount\":347831733378979676353},{\"BlockNumber\":19915448,\"From\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":347831733378979676353},{\"BlockNumber\":19967889,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"Amount\":9545079536542628972},{\"BlockNumber\":19968802,\"From\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":2562505583144619516},{\"BlockNumber\":19984988,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"Amount\":236437034480408262},{\"BlockNumber\":19984988,\"From\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":236437034480408262},{\"BlockNumber\":19984996,\"From\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":17750179354765280},{\"BlockNumber\":20124247,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":100000000000000000},{\"BlockNumber\":20131121,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x30633a1c45f011c0fdb56d97ac101080098e19f5\",\"Amount\":9528275450039656},{\"BlockNumber\":20225034,\"From\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49406435186184348},{\"BlockNumber\":20311169,\"From\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9545079536542628972}]"
-	//rd := "[{\"BlockNumber\":19532094,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":100000000000000000},{\"BlockNumber\":19538330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":10000000000000000},{\"BlockNumber\":19573517,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":100000000000000000},{\"BlockNumber\":19573835,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":19573935,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa1197129322ab316d1281b81b4f0784790e06882\",\"Amount\":32000000000000000000},{\"BlockNumber\":19616594,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":9994243034538440148},{\"BlockNumber\":19617132,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":4987091044711389999981},{\"BlockNumber\":19667602,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9987364033987884},{\"BlockNumber\":19717846,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2f2f9b1a04047bd2a5e458d24fb43874f59e3226\",\"Amount\":49903024585807770},{\"BlockNumber\":19737886,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":74836021892554535216},{\"BlockNumber\":19745301,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":997722290533019699},{\"BlockNumber\":19745726,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"Amount\":997717055597851439573},{\"BlockNumber\":19751177,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":99764991745340651},{\"BlockNumber\":19751701,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"Amount\":3990573854249433156},{\"BlockNumber\":19751999,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"Amount\":50081517620308770651},{\"BlockNumber\":20124538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"Amount\":9934943447437939},{\"BlockNumber\":20332501,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":20332518,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10000000000000000},{\"BlockNumber\":20337181,\"From\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":3990573854249433156},{\"BlockNumber\":20341096,\"From\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50081517620308770651}]"
-	//events := []TransferEvent{}
-	//err := json.Unmarshal([]byte(d), &events)
-	//if err != nil {
-	//t.Fatal(err)
-	//}
-
-	// 7-9
-	d := "[{\"BlockNumber\":16696696,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xef76d4e75154739f75f6068b3470c7968cc3fcd1\",\"Amount\":100000000000000000},{\"BlockNumber\":16697199,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16712762,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":2000000000000000000},{\"BlockNumber\":16738180,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16742506,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":32000000000000000000},{\"BlockNumber\":16752852,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xbb673b6a571c68e734d17aca9dd28ed6518deb5b\",\"Amount\":40000000000000000},{\"BlockNumber\":16797724,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":32000000000000000000},{\"BlockNumber\":16804644,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x16cb658ad7d1bcdc3ed82edb7a3bbf62010e92fe\",\"Amount\":10000000000000000},{\"BlockNumber\":16868561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":766000000000000000000},{\"BlockNumber\":16894330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"Amount\":1000000000000000000},{\"BlockNumber\":16894378,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":500000000000000000},{\"BlockNumber\":16894380,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"Amount\":1086206863302042},{\"BlockNumber\":16894388,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":474748794029138918},{\"BlockNumber\":16894405,\"From\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1086206863302042},{\"BlockNumber\":16922990,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"Amount\":9993157647393183},{\"BlockNumber\":16923061,\"From\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":9993157647393183},{\"BlockNumber\":16988058,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":9984433062832875},{\"BlockNumber\":17051688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":29936427888275051239},{\"BlockNumber\":17057861,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd98673532035b029d4a3a0db26481781c5373e6f\",\"Amount\":9978809296091683746},{\"BlockNumber\":17129073,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"Amount\":99635257935296669},{\"BlockNumber\":17129082,\"From\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":17135448,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2692246865295780245673},{\"BlockNumber\":17170922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":16041276527582763801},{\"BlockNumber\":17179269,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":30000000000000000000},{\"BlockNumber\":17199736,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"Amount\":15932122553750811079},{\"BlockNumber\":17215932,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"Amount\":2001202182403918126},{\"BlockNumber\":17233805,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"Amount\":4479437575836008436},{\"BlockNumber\":17241326,\"From\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4479430000000000000},{\"BlockNumber\":17270942,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":32842572116743766140},{\"BlockNumber\":17290950,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":1523660899754596108200},{\"BlockNumber\":17291076,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":31936420000000000000},{\"BlockNumber\":17333785,\"From\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":15932120000000000000},{\"BlockNumber\":17341573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771406230365327},{\"BlockNumber\":17398313,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2979011328614270682},{\"BlockNumber\":17425808,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000},{\"BlockNumber\":17432573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720913477045793},{\"BlockNumber\":17434794,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"Amount\":99275408142455514},{\"BlockNumber\":17484564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"Amount\":9922316967587324},{\"BlockNumber\":17489121,\"From\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9920000000000000},{\"BlockNumber\":17518915,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"Amount\":991956246248792912},{\"BlockNumber\":17533564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd664cebc8e0032919d7a7c8e01d676f6fad02f50\",\"Amount\":494522969307008037},{\"BlockNumber\":17571319,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x5d799e0a223fd22618a06286dc48a4796f325f5a\",\"Amount\":25701487236170348},{\"BlockNumber\":17597848,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"Amount\":98812870372368696},{\"BlockNumber\":17605658,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"Amount\":49406435186184348},{\"BlockNumber\":17605731,\"From\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":991950000000000000},{\"BlockNumber\":17617688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3062621338913464424},{\"BlockNumber\":17668205,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"Amount\":9871958646351160817},{\"BlockNumber\":17755337,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"Amount\":55202741790957015},{\"BlockNumber\":17768176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"Amount\":2562505583144619516},{\"BlockNumber\":17807538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"Amount\":4924935454536839130},{\"BlockNumber\":17809224,\"From\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9871950000000000000},{\"BlockNumber\":17839858,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"Amount\":983024677252183256},{\"BlockNumber\":17917449,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2946252706668955110},{\"BlockNumber\":17979156,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1000000000000000000},{\"BlockNumber\":17979183,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"Amount\":121260756771124243},{\"BlockNumber\":17979192,\"From\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":121260756771124243},{\"BlockNumber\":17979259,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1001200000000000000},{\"BlockNumber\":18162476,\"From\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":29980000000000000},{\"BlockNumber\":18176933,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":33842572116743766140},{\"BlockNumber\":18177023,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":32787771406230365327},{\"BlockNumber\":18177028,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":23329720913477045793},{\"BlockNumber\":18190333,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2934904865339960985},{\"BlockNumber\":18234913,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2933775493550301839175},{\"BlockNumber\":18255686,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3630ab305635199133315097b31099a44ee13497\",\"Amount\":146611070809985799},{\"BlockNumber\":18309439,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"Amount\":97615395041254911},{\"BlockNumber\":18340838,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa57c927df5b836d203560a5b568af75831939eed\",\"Amount\":9758395522519076},{\"BlockNumber\":18353006,\"From\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97610000000000000},{\"BlockNumber\":18410620,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6c660815908bdf2ce8e6907be71aceab742b16a0\",\"Amount\":9748301710359976},{\"BlockNumber\":18411198,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":1934063059335419394091},{\"BlockNumber\":18411361,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"Amount\":10333199812981575391},{\"BlockNumber\":18428065,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771400000000000},{\"BlockNumber\":18428073,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32787770000000000000},{\"BlockNumber\":18434967,\"From\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10333190000000000000},{\"BlockNumber\":18447117,\"From\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":55200000000000000},{\"BlockNumber\":18488309,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":4904806536605318186928},{\"BlockNumber\":18549529,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2919036432784638382},{\"BlockNumber\":18568176,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720910000000000},{\"BlockNumber\":18568611,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":23329720000000000000},{\"BlockNumber\":18568634,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":33842572126451177260},{\"BlockNumber\":18568646,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":33842570000000000000},{\"BlockNumber\":18632085,\"From\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":983020000000000000},{\"BlockNumber\":18880981,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":90000000000000000000},{\"BlockNumber\":18881087,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881093,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881105,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":818000000000000000000},{\"BlockNumber\":18961465,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"Amount\":12453570089929040},{\"BlockNumber\":18961583,\"From\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":12453570089929040},{\"BlockNumber\":18998925,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd91a4319bd678a590edfe54b3269724723d5d8e5\",\"Amount\":918476238821690554},{\"BlockNumber\":19032559,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3865594373350226542},{\"BlockNumber\":19110776,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x624b54d7007aa7dc90fdbf9e7ca424710a23b2d5\",\"Amount\":125279769118651925},{\"BlockNumber\":19127919,\"From\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":99270000000000000},{\"BlockNumber\":19152293,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":174613448052857980717},{\"BlockNumber\":19191894,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":241041491744028613766},{\"BlockNumber\":19230922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":5783036497886009192},{\"BlockNumber\":19261042,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":313083035805725396427},{\"BlockNumber\":19284103,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":481521267382216291},{\"BlockNumber\":19288561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":5007821180775049432},{\"BlockNumber\":19325360,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97000000000000000000},{\"BlockNumber\":19330097,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":97100730794736286139},{\"BlockNumber\":19338760,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":92389911865460853823},{\"BlockNumber\":19340671,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000000},{\"BlockNumber\":19341568,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":54786130000000000000},{\"BlockNumber\":19359798,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":121836363182737794},{\"BlockNumber\":19387771,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32000000000000000000},{\"BlockNumber\":19388776,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"Amount\":118901199549875},{\"BlockNumber\":19388785,\"From\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000},{\"BlockNumber\":19437462,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9610157615933493},{\"BlockNumber\":19437489,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":105711733775268429},{\"BlockNumber\":19437549,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":19445444,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49000000000000000000},{\"BlockNumber\":19488988,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"Amount\":5000000000000000000},{\"BlockNumber\":19489057,\"From\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5000000000000000000},{\"BlockNumber\":19490020,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":153529040000000000000},{\"BlockNumber\":19501310,\"From\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":98810000000000000},{\"BlockNumber\":19516331,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":73055630000000000000},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"Amount\":28518061848502766},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"Amount\":949219533396014760},{\"BlockNumber\":19523287,\"From\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":28518061848502764},{\"BlockNumber\":19523323,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":94921953339601476},{\"BlockNumber\":19523336,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":854297580056413284},{\"BlockNumber\":19537830,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":95975018848244923},{\"BlockNumber\":19537835,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":19538291,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9597442141483715},{\"BlockNumber\":19573557,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":121836363182737794},{\"BlockNumber\":19610245,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19610259,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19614913,\"From\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4924935454536839130},{\"BlockNumber\":19620820,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xf719ae6b7ca7be6b95e09a0bee44e9ae6fbc5b35\",\"Amount\":95867553568404505},{\"BlockNumber\":19667605,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9580702825451906},{\"BlockNumber\":19673513,\"From\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9580702825451906},{\"BlockNumber\":19724787,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19724798,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19736649,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000000},{\"BlockNumber\":19737170,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4997000000000000000000},{\"BlockNumber\":19737911,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":96000000000000000000},{\"BlockNumber\":19745241,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":957066890856151182},{\"BlockNumber\":19745680,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":957066890000000000},{\"BlockNumber\":19751176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":95699019687904983},{\"BlockNumber\":19755185,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x36fc96ab32d3a7192cbaf5fa40aba4e9dbac22de\",\"Amount\":9569383940971178},{\"BlockNumber\":19771279,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"Amount\":17750179354765280},{\"BlockNumber\":19831097,\"From\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"To\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"Amount\":347831733378979676353},{\"BlockNumber\":19915448,\"From\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":347831733378979676353},{\"BlockNumber\":19967889,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"Amount\":9545079536542628972},{\"BlockNumber\":19968802,\"From\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":2562505583144619516},{\"BlockNumber\":19984988,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"Amount\":236437034480408262},{\"BlockNumber\":19984988,\"From\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":236437034480408262},{\"BlockNumber\":19984996,\"From\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":17750179354765280},{\"BlockNumber\":20124247,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":100000000000000000},{\"BlockNumber\":20131121,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x30633a1c45f011c0fdb56d97ac101080098e19f5\",\"Amount\":9528275450039656},{\"BlockNumber\":20225034,\"From\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49406435186184348},{\"BlockNumber\":20311169,\"From\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9545079536542628972},{\"BlockNumber\":20468101,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xcf4012c4149995d2cebaac54048c8956e3d30937\",\"Amount\":10000000000000000},{\"BlockNumber\":20468802,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000},{\"BlockNumber\":20516882,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":20562412,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3cc380d7dd71c1f3c016cfc6ad3bbeef4d5b6dcb\",\"Amount\":35320225316807565},{\"BlockNumber\":20580700,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":957066890000000000},{\"BlockNumber\":20580827,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":957066890856151182},{\"BlockNumber\":20581965,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6ed4a6af4e2f316063a805c6089f8052d31a7274\",\"Amount\":9481878071009407},{\"BlockNumber\":20582368,\"From\":\"0x6ed4a6af4e2f316063a805c6089f8052d31a7274\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4000000000000000},{\"BlockNumber\":20582800,\"From\":\"0x3cc380d7dd71c1f3c016cfc6ad3bbeef4d5b6dcb\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":35320225316807565},{\"BlockNumber\":20669102,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":5983768549665785343},{\"BlockNumber\":20711848,\"From\":\"0x3630ab305635199133315097b31099a44ee13497\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":146611070809985799},{\"BlockNumber\":20717324,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":107999990000000106},{\"BlockNumber\":20861889,\"From\":\"0xa57c927df5b836d203560a5b568af75831939eed\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":9758395522519076}]"
-	events := []TransferEvent{}
-	err := json.Unmarshal([]byte(d), &events)
+func testStakerPoints(t *testing.T, eventsPath, expectedPath string, cfg points.Config) {
+	events, err := getEvents(eventsPath)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	contractAddr := nethPoolV2
-	balance := map[common.Address]*BalanceInfo{}
-	for _, event := range events {
-		addrs := []common.Address{}
-		isAdds := []bool{}
-
-		if event.From == zeroAddr || event.From == uniSwap { // mint & buy
-			addrs = append(addrs, event.To)
-			isAdds = append(isAdds, true)
-		}
-
-		if event.To == zeroAddr || event.To == contractAddr || event.To == uniSwap { // burn
-			if event.From != contractAddr {
-				addrs = append(addrs, event.From)
-				isAdds = append(isAdds, false)
-			}
-		}
-
-		// ignore zklink transfer
-		if event.To != contractAddr && event.From != contractAddr && event.To != zeroAddr && event.From != zeroAddr && event.To != uniSwap && event.From != uniSwap && event.To != zklink && event.From != zklink { // transfer
-			addrs = append(addrs, event.From)
-			isAdds = append(isAdds, false)
-
-			addrs = append(addrs, event.To)
-			isAdds = append(isAdds, true)
-		}
-
-		for i, addr := range addrs {
-			isAdd := isAdds[i]
-			if _, ok := balance[addr]; ok { // mint
-				if balance[addr].isCalcEnd {
-					continue
-				}
-				if event.BlockNumber > endBlock {
-					if balance[addr].BlockNumber == 0 {
-						continue
-					}
-
-					day := (endBlock - balance[addr].BlockNumber) / 7200
-					newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					balance[addr].CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-					balance[addr].isCalcEnd = true
-					balance[addr].BlockNumber = endBlock
-					continue
-				}
-				if balance[addr].CumulativeBalance.Cmp(big.NewInt(0)) == 0 {
-					block := balance[addr].BlockNumber
-					if startBlock > block {
-						block = startBlock
-					}
-
-					if event.BlockNumber > block {
-						day := (event.BlockNumber - block) / 7200
-						balance[addr].CumulativeBalance = big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					}
-				} else if balance[addr].CumulativeBalance.Cmp(big.NewInt(0)) != 0 {
-					day := (event.BlockNumber - balance[addr].BlockNumber) / 7200
-					newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					balance[addr].CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-				}
-				if isAdd {
-					balance[addr].Balance = big.NewInt(0).Add(balance[addr].Balance, event.Amount)
-				} else {
-					if balance[addr].Balance.Cmp(event.Amount) < 0 {
-						t.Log(addr, balance[addr].Balance, "====", event.BlockNumber, event.From, event.To, event.Amount)
-						panic("abnormal")
-					}
-					balance[addr].Balance = big.NewInt(0).Sub(balance[addr].Balance, event.Amount)
-				}
-				balance[addr].BlockNumber = event.BlockNumber
-			} else {
-				if event.BlockNumber > endBlock {
-					balance[addr] = &BalanceInfo{
-						Balance:           big.NewInt(0),
-						BlockNumber:       0,
-						CumulativeBalance: big.NewInt(0),
-					}
-					continue
-				}
-				balance[addr] = &BalanceInfo{
-					Balance:           event.Amount,
-					BlockNumber:       event.BlockNumber,
-					CumulativeBalance: big.NewInt(0),
-				}
-			}
-		}
-
+	pointInfo, err := points.Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	balanceMap := make(map[common.Address]string)
-	for addr, b := range balance {
-		if b.BlockNumber < endBlock {
-			stark := b.BlockNumber
-			if b.BlockNumber < startBlock {
-				stark = startBlock
-			}
-			day := (endBlock - stark) / 7200
+	expected, err := getPoints(expectedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-			newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-			b.CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-		}
-		if addr == uniSwap || addr == zklink {
-			continue
+	actual := pointsIn(pointInfo, defaultPointUnit)
+	if len(actual) != len(expected) {
+		t.Fatalf("points length mismatch: got %d, want %d", len(actual), len(expected))
+	}
+	for addr, point := range actual {
+		if expected[addr] != point {
+			t.Errorf("points mismatch for %s: got %s, want %s", addr, point, expected[addr])
 		}
-
-		t.Log("Balance", addr, WEIToGWEI(b.Balance))
-		t.Log("CumulativeBalance", addr, WEIToGWEI(b.CumulativeBalance))
-		t.Log("---------------")
-		balanceMap[addr] = WEIToGWEI(b.CumulativeBalance)
 	}
-	b, _ := json.MarshalIndent(balanceMap, "", " ")
-	t.Log(string(b))
 }
 
-func TestRNethStakerBalance(t *testing.T) {
-	_ = logging.SetLogLevel("*", "INFO")
-
-	//events, err := ScanTokenInfo(uint64(rnethStartBlock), "https://api.chainup.net/ethereum-archive/mainnet/37dc27cef7a444c682e8f6266580a588", rnethToken)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-	//
-	//for _, event := range events {
-	//	t.Log(event)
-	//}
-	//data, err := json.Marshal(events)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-	//fmt.Println(string(data))
-
-	//d := "[{\"BlockNumber\":16696696,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xef76d4e75154739f75f6068b3470c7968cc3fcd1\",\"Amount\":100000000000000000},{\"BlockNumber\":16697199,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16712762,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":2000000000000000000},{\"BlockNumber\":16738180,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":10000000000000000},{\"BlockNumber\":16742506,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":32000000000000000000},{\"BlockNumber\":16752852,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xbb673b6a571c68e734d17aca9dd28ed6518deb5b\",\"Amount\":40000000000000000},{\"BlockNumber\":16797724,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":32000000000000000000},{\"BlockNumber\":16804644,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x16cb658ad7d1bcdc3ed82edb7a3bbf62010e92fe\",\"Amount\":10000000000000000},{\"BlockNumber\":16868561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":766000000000000000000},{\"BlockNumber\":16894330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"Amount\":1000000000000000000},{\"BlockNumber\":16894378,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":500000000000000000},{\"BlockNumber\":16894380,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"Amount\":1086206863302042},{\"BlockNumber\":16894388,\"From\":\"0xe09b3ec816aa757332af095ed3604255793ddff0\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":474748794029138918},{\"BlockNumber\":16894405,\"From\":\"0x6a0feb37e1b03ee1b599277a7cb57192b4890917\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1086206863302042},{\"BlockNumber\":16922990,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"Amount\":9993157647393183},{\"BlockNumber\":16923061,\"From\":\"0x3876450925e6c9dc181a822a037845a5205448fd\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":9993157647393183},{\"BlockNumber\":16988058,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"Amount\":9984433062832875},{\"BlockNumber\":17051688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"Amount\":29936427888275051239},{\"BlockNumber\":17057861,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd98673532035b029d4a3a0db26481781c5373e6f\",\"Amount\":9978809296091683746},{\"BlockNumber\":17129073,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"Amount\":99635257935296669},{\"BlockNumber\":17129082,\"From\":\"0x863c1a09c9acd65ff8478882f030b37017c98dc7\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":17135448,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2692246865295780245673},{\"BlockNumber\":17170922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":16041276527582763801},{\"BlockNumber\":17179269,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":30000000000000000000},{\"BlockNumber\":17199736,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"Amount\":15932122553750811079},{\"BlockNumber\":17215932,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"Amount\":2001202182403918126},{\"BlockNumber\":17233805,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"Amount\":4479437575836008436},{\"BlockNumber\":17241326,\"From\":\"0x83af0b53226136dcdfbae8ee8eff43cb59bdf4c6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4479430000000000000},{\"BlockNumber\":17270942,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":32842572116743766140},{\"BlockNumber\":17290950,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":1523660899754596108200},{\"BlockNumber\":17291076,\"From\":\"0x6722b153c537f47515d5645ed23f9973b7a5ed88\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":31936420000000000000},{\"BlockNumber\":17333785,\"From\":\"0xaecd92aec5bfbe2f5a02db2dee90733897360983\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":15932120000000000000},{\"BlockNumber\":17341573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771406230365327},{\"BlockNumber\":17398313,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2979011328614270682},{\"BlockNumber\":17425808,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000},{\"BlockNumber\":17432573,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720913477045793},{\"BlockNumber\":17434794,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"Amount\":99275408142455514},{\"BlockNumber\":17484564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"Amount\":9922316967587324},{\"BlockNumber\":17489121,\"From\":\"0xe193e96b4946b9980462f8d159913069baac845d\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9920000000000000},{\"BlockNumber\":17518915,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"Amount\":991956246248792912},{\"BlockNumber\":17533564,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd664cebc8e0032919d7a7c8e01d676f6fad02f50\",\"Amount\":494522969307008037},{\"BlockNumber\":17571319,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x5d799e0a223fd22618a06286dc48a4796f325f5a\",\"Amount\":25701487236170348},{\"BlockNumber\":17597848,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"Amount\":98812870372368696},{\"BlockNumber\":17605658,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"Amount\":49406435186184348},{\"BlockNumber\":17605731,\"From\":\"0xc320e4ef78095f9ed0a44f457b2c47f57c2b8bda\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":991950000000000000},{\"BlockNumber\":17617688,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3062621338913464424},{\"BlockNumber\":17668205,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"Amount\":9871958646351160817},{\"BlockNumber\":17755337,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"Amount\":55202741790957015},{\"BlockNumber\":17768176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"Amount\":2562505583144619516},{\"BlockNumber\":17807538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"Amount\":4924935454536839130},{\"BlockNumber\":17809224,\"From\":\"0x405237f3b039be62692bffce30badf965f7f5e39\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9871950000000000000},{\"BlockNumber\":17839858,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"Amount\":983024677252183256},{\"BlockNumber\":17917449,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2946252706668955110},{\"BlockNumber\":17979156,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":1000000000000000000},{\"BlockNumber\":17979183,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"Amount\":121260756771124243},{\"BlockNumber\":17979192,\"From\":\"0x81352a401b713514e5ce5b0ddc4644b6b9b64ad4\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":121260756771124243},{\"BlockNumber\":17979259,\"From\":\"0xea4085e1a885048368724cbf3b8afdb85abb5a0c\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1001200000000000000},{\"BlockNumber\":18162476,\"From\":\"0x00e4a0d1225088ce73138ba5a879af6eafda6f3e\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":29980000000000000},{\"BlockNumber\":18176933,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":33842572116743766140},{\"BlockNumber\":18177023,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":32787771406230365327},{\"BlockNumber\":18177028,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"Amount\":23329720913477045793},{\"BlockNumber\":18190333,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2934904865339960985},{\"BlockNumber\":18234913,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":2933775493550301839175},{\"BlockNumber\":18255686,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3630ab305635199133315097b31099a44ee13497\",\"Amount\":146611070809985799},{\"BlockNumber\":18309439,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"Amount\":97615395041254911},{\"BlockNumber\":18340838,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa57c927df5b836d203560a5b568af75831939eed\",\"Amount\":9758395522519076},{\"BlockNumber\":18353006,\"From\":\"0x374d5221a4367fe746cc6c92d333c6ce15386fcd\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97610000000000000},{\"BlockNumber\":18410620,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x6c660815908bdf2ce8e6907be71aceab742b16a0\",\"Amount\":9748301710359976},{\"BlockNumber\":18411198,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":1934063059335419394091},{\"BlockNumber\":18411361,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"Amount\":10333199812981575391},{\"BlockNumber\":18428065,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"Amount\":32787771400000000000},{\"BlockNumber\":18428073,\"From\":\"0xb93eb4fff3feb4bbe66ca6b49e8a7e9d7ddf5960\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32787770000000000000},{\"BlockNumber\":18434967,\"From\":\"0x2a9afe56eb8802c303fd28e782fe9a5f14b0d822\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10333190000000000000},{\"BlockNumber\":18447117,\"From\":\"0xcaecc0fde6f11537b11b75c6bfc7d24951824c40\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":55200000000000000},{\"BlockNumber\":18488309,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":4904806536605318186928},{\"BlockNumber\":18549529,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":2919036432784638382},{\"BlockNumber\":18568176,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"Amount\":23329720910000000000},{\"BlockNumber\":18568611,\"From\":\"0xdf91e18e56d9a975db3118036244c77f697d4b4b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":23329720000000000000},{\"BlockNumber\":18568634,\"From\":\"0x8c5df146ecc1efb2241a6f910b6d5a43abdfb633\",\"To\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"Amount\":33842572126451177260},{\"BlockNumber\":18568646,\"From\":\"0x62aaa2fbee60cb1f4912ec524cfbe108d9efda2f\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":33842570000000000000},{\"BlockNumber\":18632085,\"From\":\"0xd54d8142593e7051a3e3a90124cfe913eb1995b5\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":983020000000000000},{\"BlockNumber\":18880981,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":90000000000000000000},{\"BlockNumber\":18881087,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881093,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":1000000000000000000000},{\"BlockNumber\":18881105,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":818000000000000000000},{\"BlockNumber\":18961465,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"Amount\":12453570089929040},{\"BlockNumber\":18961583,\"From\":\"0xad5c4f93cac4929787c92531342ec220111246dc\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":12453570089929040},{\"BlockNumber\":18998925,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xd91a4319bd678a590edfe54b3269724723d5d8e5\",\"Amount\":918476238821690554},{\"BlockNumber\":19032559,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":3865594373350226542},{\"BlockNumber\":19110776,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x624b54d7007aa7dc90fdbf9e7ca424710a23b2d5\",\"Amount\":125279769118651925},{\"BlockNumber\":19127919,\"From\":\"0x59ca75d497702251ae552e30e513d146fbed69bc\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":99270000000000000},{\"BlockNumber\":19152293,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":174613448052857980717},{\"BlockNumber\":19191894,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":241041491744028613766},{\"BlockNumber\":19230922,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":5783036497886009192},{\"BlockNumber\":19261042,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"Amount\":313083035805725396427},{\"BlockNumber\":19284103,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":481521267382216291},{\"BlockNumber\":19288561,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x58672b860cebbbc9eda472484c46d657036ee9d2\",\"Amount\":5007821180775049432},{\"BlockNumber\":19325360,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":97000000000000000000},{\"BlockNumber\":19330097,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":97100730794736286139},{\"BlockNumber\":19338760,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"Amount\":92389911865460853823},{\"BlockNumber\":19340671,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000000},{\"BlockNumber\":19341568,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":54786130000000000000},{\"BlockNumber\":19359798,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":121836363182737794},{\"BlockNumber\":19387771,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":32000000000000000000},{\"BlockNumber\":19388776,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"Amount\":118901199549875},{\"BlockNumber\":19388785,\"From\":\"0xf50087b8663177ea50e7c5428f7d0908cddb4f8f\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000},{\"BlockNumber\":19437462,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9610157615933493},{\"BlockNumber\":19437489,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":105711733775268429},{\"BlockNumber\":19437549,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":19445444,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49000000000000000000},{\"BlockNumber\":19488988,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"Amount\":5000000000000000000},{\"BlockNumber\":19489057,\"From\":\"0x0d4da7940b6ba27f495bd30cd33b25974973f5e0\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5000000000000000000},{\"BlockNumber\":19490020,\"From\":\"0xb78371d0914bc161cec84e654bbd79bf4c49ac87\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":153529040000000000000},{\"BlockNumber\":19501310,\"From\":\"0x866f98e4d2b0742110cd674214767140477bb342\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":98810000000000000},{\"BlockNumber\":19516331,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":73055630000000000000},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"Amount\":28518061848502766},{\"BlockNumber\":19523287,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"Amount\":949219533396014760},{\"BlockNumber\":19523287,\"From\":\"0x429cf888dae41d589d57f6dc685707bec755fe63\",\"To\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"Amount\":28518061848502764},{\"BlockNumber\":19523323,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":94921953339601476},{\"BlockNumber\":19523336,\"From\":\"0x3ea44328b48a027a5e7ada15c193cbc388268786\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":854297580056413284},{\"BlockNumber\":19537830,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":95975018848244923},{\"BlockNumber\":19537835,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50000000000000000},{\"BlockNumber\":19538291,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":9597442141483715},{\"BlockNumber\":19573557,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":121836363182737794},{\"BlockNumber\":19610245,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19610259,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":1000000000000000000000},{\"BlockNumber\":19614913,\"From\":\"0xb336177b760f3787e33497c67a958cc425205e58\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":4924935454536839130},{\"BlockNumber\":19620820,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xf719ae6b7ca7be6b95e09a0bee44e9ae6fbc5b35\",\"Amount\":95867553568404505},{\"BlockNumber\":19667605,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9580702825451906},{\"BlockNumber\":19673513,\"From\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9580702825451906},{\"BlockNumber\":19724787,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19724798,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":5870000000000000000000},{\"BlockNumber\":19736649,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000000},{\"BlockNumber\":19737170,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4997000000000000000000},{\"BlockNumber\":19737911,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":96000000000000000000},{\"BlockNumber\":19745241,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":957066890856151182},{\"BlockNumber\":19745680,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":957066890000000000},{\"BlockNumber\":19751176,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":95699019687904983},{\"BlockNumber\":19755185,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x36fc96ab32d3a7192cbaf5fa40aba4e9dbac22de\",\"Amount\":9569383940971178},{\"BlockNumber\":19771279,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"Amount\":17750179354765280},{\"BlockNumber\":19831097,\"From\":\"0x9dc00f109acfba2622f0fe48a522558fa4f1d509\",\"To\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"Amount\":347831733378979676353},{\"BlockNumber\":19915448,\"From\":\"0xf3c79408164abfb6fd5ddfe33b084e4ad2c07c18\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":347831733378979676353},{\"BlockNumber\":19967889,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"Amount\":9545079536542628972},{\"BlockNumber\":19968802,\"From\":\"0xc3a9bd6a917524c85af4cb88a8ef2946df473036\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":2562505583144619516},{\"BlockNumber\":19984988,\"From\":\"0x24ad0af5999dd3ca3d5d9826d34a16b0cc135c83\",\"To\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"Amount\":236437034480408262},{\"BlockNumber\":19984988,\"From\":\"0x4ab12e7ce31857ee022f273e8580f73335a73c0b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":236437034480408262},{\"BlockNumber\":19984996,\"From\":\"0x0d561db8211f26abe1d26b3d86ac7b5b2409489b\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":17750179354765280},{\"BlockNumber\":20124247,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":100000000000000000},{\"BlockNumber\":20131121,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x30633a1c45f011c0fdb56d97ac101080098e19f5\",\"Amount\":9528275450039656},{\"BlockNumber\":20225034,\"From\":\"0x0321be949876c2545ac121379c620c2a0480b758\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49406435186184348},{\"BlockNumber\":20311169,\"From\":\"0x47887d0d477b01870c1a2cbf16de7d5917e1f022\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9545079536542628972}]"
-	//events := []TransferEvent{}
-	//err := json.Unmarshal([]byte(d), &events)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-
-	// 7-9
-	d := "[{\"BlockNumber\":19532094,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":100000000000000000},{\"BlockNumber\":19538330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":10000000000000000},{\"BlockNumber\":19573517,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":100000000000000000},{\"BlockNumber\":19573835,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":19573935,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa1197129322ab316d1281b81b4f0784790e06882\",\"Amount\":32000000000000000000},{\"BlockNumber\":19616594,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":9994243034538440148},{\"BlockNumber\":19617132,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":4987091044711389999981},{\"BlockNumber\":19667602,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9987364033987884},{\"BlockNumber\":19717846,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2f2f9b1a04047bd2a5e458d24fb43874f59e3226\",\"Amount\":49903024585807770},{\"BlockNumber\":19730497,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":19732028,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000},{\"BlockNumber\":19737176,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4997000000000000000000},{\"BlockNumber\":19737886,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":74836021892554535216},{\"BlockNumber\":19737918,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":74000000000000000000},{\"BlockNumber\":19745301,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":997722290533019699},{\"BlockNumber\":19745712,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":997722290000000000},{\"BlockNumber\":19745726,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"Amount\":997717055597851439573},{\"BlockNumber\":19745778,\"From\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":997717000000000000000},{\"BlockNumber\":19751177,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":99764991745340651},{\"BlockNumber\":19751701,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"Amount\":3990573854249433156},{\"BlockNumber\":19751737,\"From\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":3990000000000000000},{\"BlockNumber\":19751999,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"Amount\":50081517620308770651},{\"BlockNumber\":19752019,\"From\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":50080000000000000000},{\"BlockNumber\":20124538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"Amount\":9934943447437939},{\"BlockNumber\":20124739,\"From\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":9934943447437939},{\"BlockNumber\":20159076,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"Amount\":3990000000000000000},{\"BlockNumber\":20159253,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"Amount\":997717000000000000000},{\"BlockNumber\":20174529,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":100000000000000000},{\"BlockNumber\":20220408,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"Amount\":50080000000000000000},{\"BlockNumber\":20332501,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":20332518,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10000000000000000},{\"BlockNumber\":20337181,\"From\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":3990573854249433156},{\"BlockNumber\":20341096,\"From\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50081517620308770651},{\"BlockNumber\":20360644,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":10000000000000000},{\"BlockNumber\":20387629,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"Amount\":9934943447437939},{\"BlockNumber\":20388815,\"From\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9934943447437939},{\"BlockNumber\":20468806,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000},{\"BlockNumber\":20526538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x74fbfe13228ec7cdac4c2a2d2b59a762717f4962\",\"Amount\":104854466194349867579},{\"BlockNumber\":20528345,\"From\":\"0x2f2f9b1a04047bd2a5e458d24fb43874f59e3226\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49903024585807770},{\"BlockNumber\":20580749,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":997722290000000000},{\"BlockNumber\":20580816,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":997722290533019699},{\"BlockNumber\":20623978,\"From\":\"0x74fbfe13228ec7cdac4c2a2d2b59a762717f4962\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":104854466194349867579},{\"BlockNumber\":20924715,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x8ba98e8483136126680d8aa2e2deb74a01152bfc\",\"Amount\":177273673780411837974},{\"BlockNumber\":20929263,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":985008507495402560866},{\"BlockNumber\":20933985,\"From\":\"0x8ba98e8483136126680d8aa2e2deb74a01152bfc\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":177000000000000000000},{\"BlockNumber\":20934029,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":985008507495402560866},{\"BlockNumber\":20934202,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":985000000000000000000},{\"BlockNumber\":20969927,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x79ff9ccd0691381fde9ba2decffcb3cbc2e71bf2\",\"Amount\":9844735026607350},{\"BlockNumber\":20970033,\"From\":\"0x79ff9ccd0691381fde9ba2decffcb3cbc2e71bf2\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":1000000000000000}]"
-	events := []TransferEvent{}
-	err := json.Unmarshal([]byte(d), &events)
+func testRoles(t *testing.T, rulesPath string) map[common.Address]points.Role {
+	rules, err := points.ReadRules(rulesPath)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	//// eigen
-	//d := "[{\"BlockNumber\":19532094,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":100000000000000000},{\"BlockNumber\":19538330,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":10000000000000000},{\"BlockNumber\":19573517,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":100000000000000000},{\"BlockNumber\":19573835,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":19573935,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xa1197129322ab316d1281b81b4f0784790e06882\",\"Amount\":32000000000000000000},{\"BlockNumber\":19616594,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":9994243034538440148},{\"BlockNumber\":19617132,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"Amount\":4987091044711389999981},{\"BlockNumber\":19667602,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x72fcf70f78168f384b40b0eeae656536f5245a29\",\"Amount\":9987364033987884},{\"BlockNumber\":19717846,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2f2f9b1a04047bd2a5e458d24fb43874f59e3226\",\"Amount\":49903024585807770},{\"BlockNumber\":19730497,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":100000000000000000},{\"BlockNumber\":19732028,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000},{\"BlockNumber\":19737176,\"From\":\"0x29c03ee3ab1bb1bd36d24c887c7be2e2b735b9fa\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":4997000000000000000000},{\"BlockNumber\":19737886,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"Amount\":74836021892554535216},{\"BlockNumber\":19737918,\"From\":\"0x2d8d8e5b2fbd060bdb29690213835737a7dba572\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":74000000000000000000},{\"BlockNumber\":19745301,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":997722290533019699},{\"BlockNumber\":19745712,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":997722290000000000},{\"BlockNumber\":19745726,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"Amount\":997717055597851439573},{\"BlockNumber\":19745778,\"From\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":997717000000000000000},{\"BlockNumber\":19751177,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"Amount\":99764991745340651},{\"BlockNumber\":19751701,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"Amount\":3990573854249433156},{\"BlockNumber\":19751737,\"From\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":3990000000000000000},{\"BlockNumber\":19751999,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"Amount\":50081517620308770651},{\"BlockNumber\":19752019,\"From\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":50080000000000000000},{\"BlockNumber\":20124538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"Amount\":9934943447437939},{\"BlockNumber\":20124739,\"From\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":9934943447437939},{\"BlockNumber\":20159076,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"Amount\":3990000000000000000},{\"BlockNumber\":20159253,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x3e360a939af645984c7251876c87bcd3d3e4e8e9\",\"Amount\":997717000000000000000},{\"BlockNumber\":20174529,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"Amount\":100000000000000000},{\"BlockNumber\":20220408,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"Amount\":50080000000000000000},{\"BlockNumber\":20332501,\"From\":\"0xdcaf3ed6e28047f4480900a39a318d8377ad36e3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":100000000000000000},{\"BlockNumber\":20332518,\"From\":\"0x3e29bf7b650b8910f3b4ddda5b146e8716c683a6\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":10000000000000000},{\"BlockNumber\":20337181,\"From\":\"0x3c385cd0ee6fc17f49c4bc900b8652c402704b38\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":3990573854249433156},{\"BlockNumber\":20341096,\"From\":\"0x37624b00a536884b45d17dfd10efdf65fdea73ce\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":50081517620308770651},{\"BlockNumber\":20360644,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"Amount\":10000000000000000},{\"BlockNumber\":20387629,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"Amount\":9934943447437939},{\"BlockNumber\":20388815,\"From\":\"0xe067340aea40ab1a5f93e95cd95d6102855ccdf3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":9934943447437939},{\"BlockNumber\":20468806,\"From\":\"0x2dd1a12636a6ee7ba0ef96f53ad77e6a383e3763\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":10000000000000000},{\"BlockNumber\":20526538,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x74fbfe13228ec7cdac4c2a2d2b59a762717f4962\",\"Amount\":104854466194349867579},{\"BlockNumber\":20528345,\"From\":\"0x2f2f9b1a04047bd2a5e458d24fb43874f59e3226\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":49903024585807770},{\"BlockNumber\":20580749,\"From\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"To\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"Amount\":997722290000000000},{\"BlockNumber\":20580816,\"From\":\"0xc94340a26eeb0d23e129bfb2474c80d4f8e7deb3\",\"To\":\"0x0000000000000000000000000000000000000000\",\"Amount\":997722290533019699},{\"BlockNumber\":20623978,\"From\":\"0x74fbfe13228ec7cdac4c2a2d2b59a762717f4962\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":104854466194349867579},{\"BlockNumber\":20924715,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x8ba98e8483136126680d8aa2e2deb74a01152bfc\",\"Amount\":177273673780411837974},{\"BlockNumber\":20929263,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"Amount\":985008507495402560866},{\"BlockNumber\":20933985,\"From\":\"0x8ba98e8483136126680d8aa2e2deb74a01152bfc\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":177000000000000000000},{\"BlockNumber\":20934029,\"From\":\"0xab31f67ae39921163652d030c6821ea322162cbc\",\"To\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"Amount\":985008507495402560866},{\"BlockNumber\":20934202,\"From\":\"0x75e4ad9c933ddd5b17012009c8eff252fb27fbe8\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":985000000000000000000},{\"BlockNumber\":20969927,\"From\":\"0x0000000000000000000000000000000000000000\",\"To\":\"0x79ff9ccd0691381fde9ba2decffcb3cbc2e71bf2\",\"Amount\":9844735026607350},{\"BlockNumber\":20970033,\"From\":\"0x79ff9ccd0691381fde9ba2decffcb3cbc2e71bf2\",\"To\":\"0xad16edcf7deb7e90096a259c81269d811544b6b6\",\"Amount\":1000000000000000}]\n"
-	//events := []TransferEvent{}
-	//err := json.Unmarshal([]byte(d), &events)
-	//if err != nil {
-	//	t.Fatal(err)
-	//}
-
-	contractAddr := rnethPool
-	balance := map[common.Address]*BalanceInfo{}
-	for _, event := range events {
-		addrs := []common.Address{}
-		isAdds := []bool{}
-
-		if event.From == zeroAddr || event.From == uniSwap { // mint & buy
-			addrs = append(addrs, event.To)
-			isAdds = append(isAdds, true)
-		}
-
-		// todo rneth uniswap
-		if event.To == zeroAddr || event.To == contractAddr || event.To == uniSwap { // burn
-			addrs = append(addrs, event.From)
-			isAdds = append(isAdds, false)
-		}
-
-		if event.To != contractAddr && event.From != contractAddr && event.To != zeroAddr && event.From != zeroAddr && event.To != uniSwap && event.From != uniSwap && event.To != zklink && event.From != zklink { // transfer
-			addrs = append(addrs, event.From)
-			isAdds = append(isAdds, false)
-
-			addrs = append(addrs, event.To)
-			isAdds = append(isAdds, true)
-		}
-
-		for i, addr := range addrs {
-
-			isAdd := isAdds[i]
-			if _, ok := balance[addr]; ok { // mint
-				if balance[addr].isCalcEnd {
-					continue
-				}
-				if event.BlockNumber > endBlock {
-					if balance[addr].BlockNumber == 0 {
-						continue
-					}
-
-					day := (endBlock - balance[addr].BlockNumber) / 7200
-					newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					balance[addr].CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-					balance[addr].isCalcEnd = true
-					balance[addr].BlockNumber = endBlock
-					continue
-				}
-
-				if balance[addr].CumulativeBalance.Cmp(big.NewInt(0)) == 0 {
-					block := balance[addr].BlockNumber
-					if startBlock > block {
-						block = startBlock
-					}
-
-					if event.BlockNumber > block {
-						day := (event.BlockNumber - block) / 7200
-						balance[addr].CumulativeBalance = big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					}
-				} else if balance[addr].CumulativeBalance.Cmp(big.NewInt(0)) != 0 {
-					day := (event.BlockNumber - balance[addr].BlockNumber) / 7200
-					newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-					balance[addr].CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-				}
-
-				if isAdd {
-					balance[addr].Balance = big.NewInt(0).Add(balance[addr].Balance, event.Amount)
-				} else {
-					if balance[addr].Balance.Cmp(event.Amount) < 0 {
-						t.Log(addr, balance[addr].Balance, "====", event.BlockNumber, event.From, event.To, event.Amount)
-						panic("abnormal")
-					}
-					balance[addr].Balance = big.NewInt(0).Sub(balance[addr].Balance, event.Amount)
-				}
-				balance[addr].BlockNumber = event.BlockNumber
-			} else {
-				block := event.BlockNumber
-				if event.BlockNumber > endBlock {
-					balance[addr] = &BalanceInfo{
-						Balance:           big.NewInt(0),
-						BlockNumber:       block,
-						CumulativeBalance: big.NewInt(0),
-					}
-					continue
-				}
-				balance[addr] = &BalanceInfo{
-					Balance:           event.Amount,
-					BlockNumber:       block,
-					CumulativeBalance: big.NewInt(0),
-				}
-			}
-		}
-
-	}
-
-	balanceMap := make(map[common.Address]string)
-	for addr, b := range balance {
-
-		if b.BlockNumber < endBlock {
-			stark := b.BlockNumber
-			if b.BlockNumber < startBlock {
-				stark = startBlock
-			}
-			day := (endBlock - stark) / 7200
-
-			newCumulativeBalance := big.NewInt(0).Mul(balance[addr].Balance, big.NewInt(int64(day)))
-			b.CumulativeBalance = big.NewInt(0).Add(balance[addr].CumulativeBalance, newCumulativeBalance)
-		}
-		t.Log("addr", addr)
-		if addr == uniSwap || addr == zklink {
-			continue
-		}
-
-		t.Log("Balance", addr, WEIToGWEI(b.Balance))
-		t.Log("CumulativeBalance", addr, WEIToGWEI(b.CumulativeBalance))
-		t.Log("---------------")
-
-		balanceMap[addr] = WEIToEth(b.CumulativeBalance).String()
+	roles, err := rules.Roles()
+	if err != nil {
+		t.Fatal(err)
 	}
+	return roles
+}
 
-	b, _ := json.MarshalIndent(balanceMap, "", " ")
-	t.Log(string(b))
+func TestNethStakerBalance(t *testing.T) {
+	testStakerPoints(t, "../data/events/neth-transfer-events.json", "../data/input/neth-point-2.json", points.Config{
+		StartBlock: 20207950,
+		EndBlock:   20866890,
+		Roles:      testRoles(t, "../data/rules/neth.json"),
+	})
 }
 
-func WEIToEth(value *big.Int) *big.Float {
-	return new(big.Float).Quo(new(big.Float).Quo(big.NewFloat(0).SetInt(value), big.NewFloat(params.GWei)), big.NewFloat(params.GWei))
+func TestRNethStakerBalance(t *testing.T) {
+	testStakerPoints(t, "../data/events/rneth-transfer-events.json", "../data/input/rneth-point-2.json", points.Config{
+		StartBlock: 20207950,
+		EndBlock:   20866890,
+		Roles:      testRoles(t, "../data/rules/rneth.json"),
+	})
 }
 
-func WEIToGWEI(value *big.Int) string {
-	return new(big.Int).Div(value, big.NewInt(params.GWei)).String()
+func TestRNethEigenStakerBalance(t *testing.T) {
+	testStakerPoints(t, "../data/events/rneth-transfer-events.json", "../data/eigen/rneth-eigen-point-1.json", points.Config{
+		StartBlock: 19516980,
+		EndBlock:   21010000,
+		Roles:      testRoles(t, "../data/rules/rneth.json"),
+	})
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/bloxapp/ssv-rewards/scanner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"syscall"
+)
+
+var (
+	snapshotBlock uint64
+	holdersPath   string
+)
+
+func init() {
+	snapshotCmd.PersistentFlags().StringVarP(&rpcHost, "rpc", "", "", "archive node rpc url")
+	snapshotCmd.PersistentFlags().StringVarP(&scanToken, "token", "", "", "token name, e.g. neth or rneth")
+	snapshotCmd.PersistentFlags().StringVarP(&scanAddress, "tokenAddress", "", "", "token contract address instead of a known token name")
+	snapshotCmd.PersistentFlags().Uint64VarP(&snapshotBlock, "block", "", 0, "snapshot block, usually the round start block")
+	snapshotCmd.PersistentFlags().StringVarP(&eventCacheDir, "cacheDir", "", "./data/cache", "event cache dir, whose addresses are snapshotted")
+	snapshotCmd.PersistentFlags().StringVarP(&holdersPath, "holdersPath", "", "", "JSON array of more addresses to snapshot")
+	snapshotCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot",
+	Short:   "snapshot token balances at a block",
+	Example: "./ssv-reward snapshot -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := takeSnapshot()
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		log.Info("snapshot successful")
+	},
+}
+
+func takeSnapshot() error {
+	token, ok := tokenAddresses[scanToken]
+	if scanAddress != "" {
+		if !common.IsHexAddress(scanAddress) {
+			return fmt.Errorf("invalid token address: %s", scanAddress)
+		}
+		token = common.HexToAddress(scanAddress)
+	} else if !ok {
+		return fmt.Errorf("unknown token %q", scanToken)
+	}
+	if snapshotBlock == 0 {
+		return fmt.Errorf("block is required")
+	}
+
+	holders, err := getHolders(token, eventCacheDir, holdersPath, snapshotBlock)
+	if err != nil {
+		return err
+	}
+	if len(holders) == 0 {
+		return fmt.Errorf("no holders to snapshot, scan first or give holdersPath")
+	}
+
+	eth1Client, cancel, err := GetEthClient(rpcHost)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Infow("take snapshot", "token", token, "block", snapshotBlock, "holders", len(holders))
+	snapshot, err := scanner.TakeSnapshot(ctx, eth1Client, token, holders, snapshotBlock)
+	if err != nil {
+		return err
+	}
+
+	name := scanToken
+	if name == "" {
+		name = token.Hex()
+	}
+	return writeJsonFile(snapshot, filepath.Join(outputDir, name+"-snapshot-"+strconv.FormatUint(snapshotBlock, 10)+".json"))
+}
+
+// getHolders returns the addresses of the cached events of token up to block
+// and of the holders file, sorted.
+func getHolders(token common.Address, cacheDir, path string, block uint64) ([]common.Address, error) {
+	seen := map[common.Address]bool{}
+
+	cache, err := scanner.NewCache(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	events, _, err := cache.Load(token)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.BlockNumber <= block {
+			seen[event.From] = true
+			seen[event.To] = true
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]common.Address, 0)
+		if err := json.Unmarshal(data, &addrs); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		for _, addr := range addrs {
+			seen[addr] = true
+		}
+	}
+
+	delete(seen, points.ZeroAddr)
+	holders := make([]common.Address, 0, len(seen))
+	for addr := range seen {
+		holders = append(holders, addr)
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].Hex() < holders[j].Hex()
+	})
+	return holders, nil
+}
@@ -2,9 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
-	"math/big"
 )
 
 var (
@@ -15,6 +13,8 @@ var (
 func init() {
 	sumCmd.PersistentFlags().StringVarP(&total1PointsInputPath, "total1PointsInputPath", "", "", "total1 points input file path")
 	sumCmd.PersistentFlags().StringVarP(&total2PointsInputPath, "total2PointsInputPath", "", "", "total2 points input file path")
+	sumCmd.PersistentFlags().StringVarP(&rewardToken, "rewardToken", "", "ssv", "reward token recorded in the reward file")
+	sumCmd.PersistentFlags().Uint64VarP(&rewardRound, "round", "", 0, "reward round recorded in the reward file")
 	sumCmd.PersistentFlags().StringVarP(&outputDir, "outputDir", "", "", "output dir")
 }
 
@@ -32,40 +32,33 @@ var sumCmd = &cobra.Command{
 	},
 }
 
+// sumReward adds the rewards of a round, total2, to the cumulative rewards of
+// the previous rounds, total1. Reward files with an envelope must be of the
+// reward token, and total1 of a round before the reward round if it is known.
 func sumReward() error {
-	total1Points, err := getPoints(total1PointsInputPath)
+	total1Rewards, round1, err := getRewards(total1PointsInputPath, rewardToken)
 	if err != nil {
 		return err
 	}
+	if rewardRound != 0 && round1 >= rewardRound {
+		return fmt.Errorf("%s is of round %d, not before round %d", total1PointsInputPath, round1, rewardRound)
+	}
 
-	total2Points, err := getPoints(total2PointsInputPath)
+	total2Rewards, round2, err := getRewards(total2PointsInputPath, rewardToken)
 	if err != nil {
 		return err
 	}
-
-	totalPoints := make(map[common.Address]*big.Int)
-	for key, value := range total1Points {
-		point, isOk := big.NewInt(0).SetString(value, 10)
-		if !isOk {
-			return fmt.Errorf("amount parsing failed")
-		}
-		addr := common.HexToAddress(key)
-		totalPoints[addr] = point
-	}
-	for key2, value2 := range total2Points {
-		addr2 := common.HexToAddress(key2)
-		point2, isOk := big.NewInt(0).SetString(value2, 10)
-		if !isOk {
-			return fmt.Errorf("amount parsing failed")
-		}
-		if _, ok := totalPoints[addr2]; ok {
-			totalPoints[addr2] = big.NewInt(0).Add(totalPoints[addr2], point2)
-		} else {
-			totalPoints[addr2] = point2
-		}
+	if rewardRound != 0 && round2 > rewardRound {
+		return fmt.Errorf("%s is of round %d, after round %d", total2PointsInputPath, round2, rewardRound)
 	}
 
-	err = writeJson(totalPoints, "total-final", outputDir)
+	totalPoints := mergeRewards(total1Rewards, total2Rewards)
+
+	rewardFile, err := newRewardFile(rewardToken, rewardRound, totalPoints, total1PointsInputPath, total2PointsInputPath)
+	if err != nil {
+		return err
+	}
+	err = writeRewards(rewardFile, "total-final", outputDir)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/merkle"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	merkleInputPath string
+)
+
+func init() {
+	verifyMerkleCmd.PersistentFlags().StringVarP(&merkleInputPath, "merkleInputPath", "", "", "merkle proof input file path")
+	verifyMerkleCmd.PersistentFlags().StringVarP(&rewardInputPath, "rewardInputPath", "", "", "cumulative reward input file path")
+}
+
+var verifyMerkleCmd = &cobra.Command{
+	Use:     "verify-merkle",
+	Short:   "verify merkle proofs against the cumulative rewards",
+	Example: "./ssv-reward verify-merkle -h",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := verifyMerkle()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		log.Info("merkle verification successful")
+	},
+}
+
+func verifyMerkle() error {
+	distribution, err := getDistribution(merkleInputPath)
+	if err != nil {
+		return err
+	}
+
+	rewards, err := getPoints(rewardInputPath)
+	if err != nil {
+		return err
+	}
+
+	rewardInfo, err := parseRewards(rewards)
+	if err != nil {
+		return err
+	}
+
+	mismatches := merkle.Check(distribution, rewardInfo)
+	for _, mismatch := range mismatches {
+		log.Errorw("merkle mismatch", "address", mismatch.Address, "reason", mismatch.Reason)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d mismatches in %s (root %s)", len(mismatches), merkleInputPath, distribution.Root.Hex())
+	}
+
+	log.Infow("merkle verified", "root", distribution.Root.Hex(), "claims", len(distribution.Data))
+	return nil
+}
+
+func getDistribution(filePath string) (*merkle.Distribution, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := &merkle.Distribution{}
+	err = json.Unmarshal(data, distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return distribution, nil
+}
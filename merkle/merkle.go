@@ -0,0 +1,202 @@
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"sort"
+)
+
+// Claim is one entry of a published distribution.
+type Claim struct {
+	Address string        `json:"address"`
+	Amount  string        `json:"amount"`
+	Proof   []common.Hash `json:"proof"`
+}
+
+// Distribution is the merkle root together with every claim and its proof,
+// in the shape consumed by CumulativeMerkleDrop claimers.
+type Distribution struct {
+	Root common.Hash `json:"root"`
+	Data []Claim     `json:"data"`
+}
+
+// LeafHash returns keccak256(abi.encodePacked(account, cumulativeAmount)).
+func LeafHash(account common.Address, cumulativeAmount *big.Int) common.Hash {
+	return crypto.Keccak256Hash(account.Bytes(), math.U256Bytes(new(big.Int).Set(cumulativeAmount)))
+}
+
+// hashPair hashes two nodes in ascending order, as _verifyAsm does.
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a[:], b[:])
+}
+
+// Tree is a merkle tree with sorted-pair hashing. An odd node at the end of a
+// layer is promoted to the next layer unchanged.
+type Tree struct {
+	layers [][]common.Hash
+}
+
+// NewTree builds the tree over the given leaf hashes, keeping their order.
+func NewTree(leaves []common.Hash) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, fmt.Errorf("no leaves")
+	}
+
+	layer := make([]common.Hash, len(leaves))
+	copy(layer, leaves)
+	layers := [][]common.Hash{layer}
+	for len(layer) > 1 {
+		next := make([]common.Hash, 0, (len(layer)+1)/2)
+		for i := 0; i < len(layer); i += 2 {
+			if i+1 == len(layer) {
+				next = append(next, layer[i])
+				continue
+			}
+			next = append(next, hashPair(layer[i], layer[i+1]))
+		}
+		layers = append(layers, next)
+		layer = next
+	}
+
+	return &Tree{layers: layers}, nil
+}
+
+// Root returns the merkle root.
+func (t *Tree) Root() common.Hash {
+	return t.layers[len(t.layers)-1][0]
+}
+
+// Proof returns the proof of the leaf at index.
+func (t *Tree) Proof(index int) []common.Hash {
+	proof := make([]common.Hash, 0, len(t.layers))
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		pair := index + 1
+		if index%2 == 1 {
+			pair = index - 1
+		}
+		if pair < len(layer) {
+			proof = append(proof, layer[pair])
+		}
+		index /= 2
+	}
+	return proof
+}
+
+// Verify checks the proof of leaf against root the way
+// CumulativeMerkleDrop._verifyAsm does.
+func Verify(proof []common.Hash, root, leaf common.Hash) bool {
+	for _, node := range proof {
+		leaf = hashPair(leaf, node)
+	}
+	return leaf == root
+}
+
+// NewDistribution builds the distribution of the cumulative rewards. Leaves are
+// ordered by checksummed address, matching the key order of the reward files.
+func NewDistribution(rewards map[common.Address]*big.Int) (*Distribution, error) {
+	addrs := make([]common.Address, 0, len(rewards))
+	for addr, amount := range rewards {
+		if amount.Sign() < 0 || amount.BitLen() > 256 {
+			return nil, fmt.Errorf("invalid amount for %s: %s", addr, amount)
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Hex() < addrs[j].Hex()
+	})
+
+	leaves := make([]common.Hash, len(addrs))
+	for i, addr := range addrs {
+		leaves[i] = LeafHash(addr, rewards[addr])
+	}
+
+	tree, err := NewTree(leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := &Distribution{
+		Root: tree.Root(),
+		Data: make([]Claim, len(addrs)),
+	}
+	for i, addr := range addrs {
+		distribution.Data[i] = Claim{
+			Address: addr.Hex(),
+			Amount:  rewards[addr].String(),
+			Proof:   tree.Proof(i),
+		}
+	}
+
+	return distribution, nil
+}
+
+// Mismatch is a problem found while checking a distribution.
+type Mismatch struct {
+	Address string
+	Reason  string
+}
+
+func (m Mismatch) String() string {
+	return m.Address + ": " + m.Reason
+}
+
+// Check recomputes every leaf of the distribution, verifies its proof against
+// the root and compares the amounts with the expected cumulative rewards.
+func Check(distribution *Distribution, rewards map[common.Address]*big.Int) []Mismatch {
+	mismatches := make([]Mismatch, 0)
+	seen := make(map[common.Address]bool, len(distribution.Data))
+	for _, claim := range distribution.Data {
+		if !common.IsHexAddress(claim.Address) {
+			mismatches = append(mismatches, Mismatch{Address: claim.Address, Reason: "invalid address"})
+			continue
+		}
+		addr := common.HexToAddress(claim.Address)
+		if seen[addr] {
+			mismatches = append(mismatches, Mismatch{Address: claim.Address, Reason: "duplicate claim"})
+			continue
+		}
+		seen[addr] = true
+
+		amount, ok := big.NewInt(0).SetString(claim.Amount, 10)
+		if !ok || amount.Sign() < 0 || amount.BitLen() > 256 {
+			mismatches = append(mismatches, Mismatch{Address: claim.Address, Reason: "invalid amount " + claim.Amount})
+			continue
+		}
+
+		if !Verify(claim.Proof, distribution.Root, LeafHash(addr, amount)) {
+			mismatches = append(mismatches, Mismatch{Address: claim.Address, Reason: "invalid proof"})
+		}
+
+		expected, ok := rewards[addr]
+		if !ok {
+			mismatches = append(mismatches, Mismatch{Address: claim.Address, Reason: "not in rewards"})
+		} else if expected.Cmp(amount) != 0 {
+			mismatches = append(mismatches, Mismatch{
+				Address: claim.Address,
+				Reason:  fmt.Sprintf("amount %s, expected %s", amount, expected),
+			})
+		}
+	}
+
+	missing := make([]common.Address, 0)
+	for addr := range rewards {
+		if !seen[addr] {
+			missing = append(missing, addr)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Hex() < missing[j].Hex()
+	})
+	for _, addr := range missing {
+		mismatches = append(mismatches, Mismatch{Address: addr.Hex(), Reason: "missing claim"})
+	}
+
+	return mismatches
+}
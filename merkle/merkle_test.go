@@ -0,0 +1,119 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"os"
+	"testing"
+)
+
+func readRewards(t *testing.T, path string) map[common.Address]*big.Int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewardStr := map[string]string{}
+	if err := json.Unmarshal(data, &rewardStr); err != nil {
+		t.Fatal(err)
+	}
+
+	rewards := make(map[common.Address]*big.Int, len(rewardStr))
+	for key, value := range rewardStr {
+		amount, ok := big.NewInt(0).SetString(value, 10)
+		if !ok {
+			t.Fatalf("invalid amount %s", value)
+		}
+		rewards[common.HexToAddress(key)] = amount
+	}
+	return rewards
+}
+
+// TestNewDistributionPublished checks that the published distributions are
+// reproduced byte-for-byte.
+func TestNewDistributionPublished(t *testing.T) {
+	cases := []struct {
+		rewardPath string
+		merklePath string
+	}{
+		{"../data/total-final-reward-2024-10-22T12:39:05.json", "../data/ssv_merkle.txt"},
+		{"../data/final-eigen-reward-2024-10-22T13:00:48.json", "../data/eigen_merkle.txt"},
+		{"../scripts/merkle-generator/scripts/input_1.json", "../scripts/merkle-generator/output_1.json"},
+	}
+
+	for _, c := range cases {
+		distribution, err := NewDistribution(readRewards(t, c.rewardPath))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := json.Marshal(distribution)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := os.ReadFile(c.merklePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(actual, bytes.TrimSpace(expected)) {
+			t.Errorf("distribution mismatch for %s, root %s", c.merklePath, distribution.Root)
+		}
+	}
+}
+
+func TestTreeProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := make([]common.Hash, n)
+		for i := range leaves {
+			leaves[i] = LeafHash(common.BigToAddress(big.NewInt(int64(i+1))), big.NewInt(int64(i)))
+		}
+
+		tree, err := NewTree(leaves)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, leaf := range leaves {
+			if !Verify(tree.Proof(i), tree.Root(), leaf) {
+				t.Errorf("invalid proof for leaf %d of %d", i, n)
+			}
+		}
+		if n > 1 && Verify(tree.Proof(0), tree.Root(), leaves[1]) {
+			t.Errorf("proof of leaf 0 verified leaf 1 of %d", n)
+		}
+	}
+
+	if _, err := NewTree(nil); err == nil {
+		t.Fatal("expected error for empty tree")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	data, err := os.ReadFile("../data/ssv_merkle.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	distribution := &Distribution{}
+	if err := json.Unmarshal(data, distribution); err != nil {
+		t.Fatal(err)
+	}
+
+	rewards := readRewards(t, "../data/total-final-reward-2024-10-22T12:39:05.json")
+	if mismatches := Check(distribution, rewards); len(mismatches) != 0 {
+		t.Fatalf("unexpected mismatches: %v", mismatches)
+	}
+
+	extra := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	rewards[extra] = big.NewInt(1)
+	distribution.Data[0].Amount = "1"
+	distribution.Data[1].Proof = distribution.Data[1].Proof[1:]
+
+	mismatches := Check(distribution, rewards)
+	if len(mismatches) != 4 {
+		t.Fatalf("expected 4 mismatches, got %v", mismatches)
+	}
+}
@@ -0,0 +1,63 @@
+package points
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// Likely causes of an anomaly.
+const (
+	// CausePreWindowHistory means no credit of the address was seen: the events
+	// most likely start after its tokens were received.
+	CausePreWindowHistory = "pre-window-history"
+	// CauseUnclassifiedContract means the address received tokens through
+	// transfers that credit nobody, from a pool or bridge: the sender most likely
+	// needs another role, or the address is a contract that needs one.
+	CauseUnclassifiedContract = "unclassified-contract"
+	CauseUnknown              = "unknown"
+)
+
+// Anomaly is a debit exceeding the tracked balance of an address.
+type Anomaly struct {
+	Address     common.Address `json:"address"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      common.Hash    `json:"txHash"`
+	LogIndex    uint           `json:"logIndex"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	// Amount is the debit, more than the tracked Balance.
+	Amount  *big.Int `json:"amount"`
+	Balance *big.Int `json:"balance"`
+	Cause   string   `json:"cause"`
+}
+
+func (a Anomaly) String() string {
+	return fmt.Sprintf("abnormal balance: address %s, balance %s, block %d, tx %s, log %d, from %s, to %s, amount %s, cause %s",
+		a.Address, a.Balance, a.BlockNumber, a.TxHash, a.LogIndex, a.From, a.To, a.Amount, a.Cause)
+}
+
+// AnomalyError fails a strict accrual, with every anomaly found.
+type AnomalyError struct {
+	Anomalies []Anomaly
+}
+
+func (e *AnomalyError) Error() string {
+	if len(e.Anomalies) == 1 {
+		return e.Anomalies[0].String()
+	}
+	return fmt.Sprintf("%d balance anomalies, first %s", len(e.Anomalies), e.Anomalies[0])
+}
+
+// anomalyCause guesses why the balance of addr is short.
+func anomalyCause(b *BalanceInfo, untracked bool) string {
+	if untracked {
+		return CauseUnclassifiedContract
+	}
+	for _, change := range b.History {
+		if change.Amount.Sign() > 0 {
+			return CauseUnknown
+		}
+	}
+	return CausePreWindowHistory
+}
@@ -0,0 +1,53 @@
+package points
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+)
+
+// Breakdown explains the points of an address: its points by source, and every
+// change of its own balance with what the balance accrued until the next one.
+type Breakdown struct {
+	Address common.Address      `json:"address"`
+	Points  *big.Int            `json:"points"`
+	Sources map[string]*big.Int `json:"sources"`
+	// HeldSince is the block the address has held tokens since, see
+	// LoyaltyTier, zero after a full exit.
+	HeldSince uint64          `json:"heldSince"`
+	Changes   []BalanceChange `json:"changes"`
+}
+
+// Breakdowns returns the breakdown of every address that earned points, sorted
+// by address, from the balances accrued by Accrue and their SourcesOf. The
+// points of the changes are turned into points by the Formula one by one, so
+// with FormulaTWAB and FormulaSamples they may add up to slightly less than
+// the SourceTransfers points.
+func Breakdowns(balance map[common.Address]*BalanceInfo, sources map[common.Address]map[string]*big.Int, cfg Config) []Breakdown {
+	breakdowns := make([]Breakdown, 0, len(sources))
+	for addr, bySource := range sources {
+		breakdown := Breakdown{
+			Address: addr,
+			Points:  big.NewInt(0),
+			Sources: bySource,
+			Changes: make([]BalanceChange, 0),
+		}
+		for _, point := range bySource {
+			breakdown.Points = big.NewInt(0).Add(breakdown.Points, point)
+		}
+		if b, ok := balance[addr]; ok {
+			if b.Balance.Sign() > 0 {
+				breakdown.HeldSince = b.HeldSince
+			}
+			for _, change := range b.History {
+				change.Points = cfg.finish(change.Points)
+				breakdown.Changes = append(breakdown.Changes, change)
+			}
+		}
+		breakdowns = append(breakdowns, breakdown)
+	}
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].Address.Hex() < breakdowns[j].Address.Hex()
+	})
+	return breakdowns
+}
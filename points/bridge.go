@@ -0,0 +1,112 @@
+package points
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"os"
+	"sort"
+)
+
+// Bridged is a feed of the balances held on the other side of a bridge, keyed
+// by L1 address. A snapshot is a feed whose balances all share one block.
+//
+// With a feed, transfers into the bridge burn the sender's tokens and transfers
+// out of it mint to the receiver, like a treasury, while the feed accrues the
+// bridged balances.
+type Bridged struct {
+	// Source names the feed, e.g. zklink.
+	Source string `json:"source"`
+	// Bridge is the bridge contract, with RoleBridge.
+	Bridge   common.Address   `json:"bridge"`
+	Balances []BridgedBalance `json:"balances"`
+}
+
+// BridgedBalance is the bridged balance of an address from a block on.
+type BridgedBalance struct {
+	Address     common.Address `json:"address"`
+	BlockNumber uint64         `json:"blockNumber"`
+	// Timestamp of the block, required by ModeSeconds.
+	Timestamp uint64   `json:"timestamp"`
+	Balance   *big.Int `json:"balance"`
+}
+
+// ReadBridged reads a bridged balance feed.
+func ReadBridged(path string) (*Bridged, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bridged := &Bridged{}
+	if err := json.Unmarshal(data, bridged); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	if bridged.Source == "" {
+		return nil, fmt.Errorf("%s: source is required", path)
+	}
+	for _, balance := range bridged.Balances {
+		if balance.Balance == nil || balance.Balance.Sign() < 0 {
+			return nil, fmt.Errorf("%s: invalid balance of %s at block %d", path, balance.Address, balance.BlockNumber)
+		}
+	}
+	return bridged, nil
+}
+
+// isFed reports whether addr is a bridge with a balance feed.
+func (c Config) isFed(addr common.Address) bool {
+	for _, bridged := range c.Bridged {
+		if bridged.Bridge == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// bridgedPoints accrues the bridged balance of every address of the feed, and
+// returns the balance info of the addresses that earned points.
+func bridgedPoints(bridged Bridged, cfg Config) (map[common.Address]*BalanceInfo, error) {
+	if cfg.Roles[bridged.Bridge] != RoleBridge {
+		return nil, fmt.Errorf("%s is not a bridge", bridged.Bridge)
+	}
+
+	balances := make([]BridgedBalance, len(bridged.Balances))
+	copy(balances, bridged.Balances)
+	sort.SliceStable(balances, func(i, j int) bool {
+		return balances[i].BlockNumber < balances[j].BlockNumber
+	})
+
+	holders := map[common.Address]*BalanceInfo{}
+	for _, balance := range balances {
+		if balance.BlockNumber > cfg.EndBlock {
+			break
+		}
+		if cfg.Mode == ModeSeconds && balance.BlockNumber >= cfg.StartBlock && balance.Timestamp == 0 {
+			return nil, fmt.Errorf("no timestamp for the balance of %s at block %d", balance.Address, balance.BlockNumber)
+		}
+		if !cfg.earns(balance.Address) {
+			continue
+		}
+
+		b, ok := holders[balance.Address]
+		if !ok {
+			b = cfg.newBalance(big.NewInt(0), balance.BlockNumber, balance.Timestamp)
+			holders[balance.Address] = b
+		}
+		cfg.settle(b, balance.BlockNumber, balance.Timestamp)
+		if err := cfg.hold(b, balance.Balance, balance.BlockNumber, balance.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	for addr, b := range holders {
+		if b.BlockNumber < cfg.EndBlock {
+			cfg.settle(b, cfg.EndBlock, cfg.EndTime)
+		}
+		if b.CumulativeBalance.Sign() == 0 {
+			delete(holders, addr)
+		}
+	}
+	return holders, nil
+}
@@ -0,0 +1,153 @@
+package points
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"sort"
+)
+
+// FormulaVersion identifies the point formulas in the point file metadata. It
+// is bumped whenever the same inputs would earn other points.
+const FormulaVersion = 1
+
+// Formula turns the balances held over the window into points.
+type Formula string
+
+const (
+	// FormulaAccrued is the balance accrued over time, in the unit of the Mode.
+	FormulaAccrued Formula = "accrued"
+	// FormulaTWAB is the time-weighted average balance: the accrued balance
+	// divided by the window length, in the unit of the Mode.
+	FormulaTWAB Formula = "twab"
+	// FormulaSamples is the average balance at the SampleBlocks.
+	FormulaSamples Formula = "samples"
+	// FormulaMinBalance is the lowest balance held over the window, so that
+	// tokens deposited for a short while earn nothing.
+	FormulaMinBalance Formula = "min-balance"
+)
+
+func ParseFormula(s string) (Formula, error) {
+	switch Formula(s) {
+	case "", FormulaAccrued:
+		return FormulaAccrued, nil
+	case FormulaTWAB, FormulaSamples, FormulaMinBalance:
+		return Formula(s), nil
+	default:
+		return "", fmt.Errorf("unknown points formula %q", s)
+	}
+}
+
+// SampleBlocks draws n distinct blocks of the window [start, end) from seed,
+// sorted. Block i is drawn as start + keccak256(seed, i) mod (end - start), i
+// counted as a big-endian uint64 and skipping the blocks already drawn, so
+// anyone can draw the same blocks from the published seed.
+func SampleBlocks(start, end uint64, n int, seed string) ([]uint64, error) {
+	if end <= start {
+		return nil, fmt.Errorf("invalid window: startBlock %d, endBlock %d", start, end)
+	}
+	if n <= 0 || uint64(n) > end-start {
+		return nil, fmt.Errorf("invalid sample count %d for %d blocks", n, end-start)
+	}
+	if seed == "" {
+		return nil, fmt.Errorf("sample seed is required")
+	}
+
+	length := new(big.Int).SetUint64(end - start)
+	drawn := make(map[uint64]bool, n)
+	blocks := make([]uint64, 0, n)
+	index := make([]byte, 8)
+	for i := uint64(0); len(blocks) < n; i++ {
+		binary.BigEndian.PutUint64(index, i)
+		h := new(big.Int).SetBytes(crypto.Keccak256([]byte(seed), index))
+		block := start + h.Mod(h, length).Uint64()
+		if drawn[block] {
+			continue
+		}
+		drawn[block] = true
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+	return blocks, nil
+}
+
+func (c Config) validateFormula() error {
+	switch c.Formula {
+	case FormulaTWAB:
+		if c.length() == 0 {
+			return fmt.Errorf("the window is shorter than one %s", c.Mode)
+		}
+	case FormulaSamples:
+		if len(c.SampleBlocks) == 0 {
+			return fmt.Errorf("no sample blocks")
+		}
+		for i, block := range c.SampleBlocks {
+			if block < c.StartBlock || block >= c.EndBlock {
+				return fmt.Errorf("sample block %d out of the window", block)
+			}
+			if i > 0 && block <= c.SampleBlocks[i-1] {
+				return fmt.Errorf("sample blocks are not sorted and distinct at block %d", block)
+			}
+		}
+	}
+	return nil
+}
+
+// length is the window length in the unit of the Mode.
+func (c Config) length() uint64 {
+	switch c.Mode {
+	case ModeSeconds:
+		return c.EndTime - c.StartTime
+	case ModeBlocks:
+		return c.EndBlock - c.StartBlock
+	default:
+		return (c.EndBlock - c.StartBlock) / BlocksPerDay
+	}
+}
+
+// sampled returns the number of sample blocks in [from, to), from clamped to
+// the window start.
+func (c Config) sampled(from, to uint64) uint64 {
+	if from < c.StartBlock {
+		from = c.StartBlock
+	}
+	if to <= from {
+		return 0
+	}
+	i := sort.Search(len(c.SampleBlocks), func(i int) bool {
+		return c.SampleBlocks[i] >= from
+	})
+	j := sort.Search(len(c.SampleBlocks), func(i int) bool {
+		return c.SampleBlocks[i] >= to
+	})
+	return uint64(j - i)
+}
+
+// newBalance returns the balance info of an address first seen at block,
+// holding balance. With FormulaMinBalance, an address first seen inside the
+// window held nothing before, so its minimum is zero.
+func (c Config) newBalance(balance *big.Int, block, timestamp uint64) *BalanceInfo {
+	return &BalanceInfo{
+		Balance:           balance,
+		BlockNumber:       block,
+		Timestamp:         timestamp,
+		CumulativeBalance: big.NewInt(0),
+		Bonus:             big.NewInt(0),
+		held:              c.Formula == FormulaMinBalance && block > c.StartBlock,
+	}
+}
+
+// finish turns the accrued balance of a source into points.
+func (c Config) finish(accrued *big.Int) *big.Int {
+	switch c.Formula {
+	case FormulaTWAB:
+		return new(big.Int).Quo(accrued, new(big.Int).SetUint64(c.length()))
+	case FormulaSamples:
+		return new(big.Int).Quo(accrued, big.NewInt(int64(len(c.SampleBlocks))))
+	default:
+		return accrued
+	}
+}
@@ -0,0 +1,139 @@
+package points
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// basisPoints is the unit of the loyalty multipliers: 10000 is 1×.
+const basisPoints = 10000
+
+// SecondsPerDay is the length of a holding day with ModeSeconds. The other
+// modes count days of BlocksPerDay blocks.
+const SecondsPerDay uint64 = 86400
+
+// SourceLoyalty is the source of the points earned by the loyalty multipliers
+// on top of the other sources.
+const SourceLoyalty = "loyalty"
+
+// LoyaltyTier multiplies the points an address accrues once it has held tokens
+// continuously for Days, by Multiplier in basis points. Holding starts when the
+// balance turns positive and restarts after a full exit; partial withdrawals
+// keep it going.
+type LoyaltyTier struct {
+	Days       uint64
+	Multiplier int64
+}
+
+func (c Config) validateLoyalty() error {
+	if len(c.Loyalty) > 0 && c.Formula == FormulaMinBalance {
+		return fmt.Errorf("loyalty multipliers do not apply to the %s formula", c.Formula)
+	}
+	for i, tier := range c.Loyalty {
+		if tier.Multiplier < basisPoints {
+			return fmt.Errorf("loyalty multiplier of %d days below 1×", tier.Days)
+		}
+		if i > 0 && tier.Days <= c.Loyalty[i-1].Days {
+			return fmt.Errorf("loyalty tiers are not sorted and distinct at %d days", tier.Days)
+		}
+	}
+	return nil
+}
+
+// hold sets the balance of b at block, whose timestamp is given, restarting
+// the holding period when the balance turns positive. The holding period of
+// loyalty multipliers over seconds starts at a timestamp, so it must be known;
+// otherwise an unknown timestamp, before the window, counts as the window start.
+func (c Config) hold(b *BalanceInfo, balance *big.Int, block, timestamp uint64) error {
+	if (b.Balance == nil || b.Balance.Sign() == 0) && balance.Sign() > 0 {
+		if timestamp == 0 {
+			if c.timed() && len(c.Loyalty) > 0 {
+				return fmt.Errorf("no timestamp for the holding start at block %d, rescan the events", block)
+			}
+			timestamp = c.StartTime
+		}
+		b.HeldSince = block
+		b.heldTime = timestamp
+	}
+	b.Balance = balance
+	return nil
+}
+
+// accrueLoyal accrues the balance of b over [from, to), splitting the range
+// where the holding period reaches a loyalty tier. from and to are blocks, or
+// timestamps with ModeSeconds.
+func (c Config) accrueLoyal(b *BalanceInfo, from, to uint64) {
+	if c.daily() {
+		c.accrueDays(b, from, to)
+		return
+	}
+
+	held, day := b.HeldSince, BlocksPerDay
+	if c.timed() {
+		held, day = b.heldTime, SecondsPerDay
+	}
+
+	multiplier := int64(basisPoints)
+	for _, tier := range c.Loyalty {
+		reached := held + tier.Days*day
+		if reached >= to {
+			break
+		}
+		if reached > from {
+			c.accrue(b, c.elapsed(from, reached), multiplier)
+			from = reached
+		}
+		multiplier = tier.Multiplier
+	}
+	c.accrue(b, c.elapsed(from, to), multiplier)
+}
+
+// accrueDays accrues the whole days of the blocks [from, to) with ModeDays. The
+// days are counted over the whole range before they are split between the
+// tiers, so that a tier never costs the partial days on both sides of it. A day
+// takes the multiplier reached at its start.
+func (c Config) accrueDays(b *BalanceInfo, from, to uint64) {
+	days := c.elapsed(from, to)
+	if from < c.StartBlock {
+		from = c.StartBlock
+	}
+
+	multiplier, counted := int64(basisPoints), uint64(0)
+	for _, tier := range c.Loyalty {
+		reached := b.HeldSince + tier.Days*BlocksPerDay
+		before := uint64(0)
+		if reached > from {
+			before = (reached - from + BlocksPerDay - 1) / BlocksPerDay
+		}
+		if before >= days {
+			break
+		}
+		if before > counted {
+			c.accrue(b, before-counted, multiplier)
+			counted = before
+		}
+		multiplier = tier.Multiplier
+	}
+	c.accrue(b, days-counted, multiplier)
+}
+
+// accrue adds the balance of b accrued over elapsed time times multiplier to
+// its cumulative balance, the part above 1× to its bonus, and to its last
+// change.
+func (c Config) accrue(b *BalanceInfo, elapsed uint64, multiplier int64) {
+	if elapsed == 0 {
+		return
+	}
+	accrued := big.NewInt(0).Mul(b.Balance, new(big.Int).SetUint64(elapsed))
+	if multiplier != basisPoints {
+		multiplied := big.NewInt(0).Mul(accrued, big.NewInt(multiplier))
+		multiplied.Quo(multiplied, big.NewInt(basisPoints))
+		b.Bonus = big.NewInt(0).Add(b.Bonus, big.NewInt(0).Sub(multiplied, accrued))
+		accrued = multiplied
+	}
+	b.CumulativeBalance = big.NewInt(0).Add(b.CumulativeBalance, accrued)
+	if n := len(b.History); n > 0 {
+		b.History[n-1].Elapsed += elapsed
+		b.History[n-1].Points = big.NewInt(0).Add(b.History[n-1].Points, accrued)
+	}
+}
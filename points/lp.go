@@ -0,0 +1,132 @@
+package points
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+)
+
+// LP attributes the token balance of a DEX pair to its liquidity providers,
+// pro rata to the LP shares they hold. Only fungible LP shares, such as those of
+// a Uniswap V2 pair, are supported.
+type LP struct {
+	// Pair is the DEX pair holding the token.
+	Pair common.Address
+	// Events are the Transfer events of the LP shares: mints from and burns to
+	// the zero address, and transfers between holders.
+	Events []TransferEvent
+}
+
+// lpStep is one change of the pair balance or of the LP shares.
+type lpStep struct {
+	event   TransferEvent
+	isShare bool
+}
+
+// lpPoints accrues the share of the pair balance held by every LP, and returns
+// the balance info of the LPs that earned points. The shares held by the zero
+// address, such as the locked minimum liquidity, and by addresses with a role
+// are not attributed.
+func lpPoints(events []TransferEvent, lp LP, cfg Config) (map[common.Address]*BalanceInfo, error) {
+	if cfg.daily() {
+		// the pair balance changes between every step, whose partial days
+		// would be dropped
+		return nil, fmt.Errorf("LP attribution needs the blocks or seconds accrual")
+	}
+
+	steps := make([]lpStep, 0, len(lp.Events))
+	for _, event := range events {
+		if event.From == lp.Pair || event.To == lp.Pair {
+			steps = append(steps, lpStep{event: event})
+		}
+	}
+	for _, event := range lp.Events {
+		steps = append(steps, lpStep{event: event, isShare: true})
+	}
+	sortSteps(steps)
+
+	pairBalance := big.NewInt(0)
+	supply := big.NewInt(0)
+	shares := map[common.Address]*big.Int{}
+	holders := map[common.Address]*BalanceInfo{}
+	for _, step := range steps {
+		event := step.event
+		if event.BlockNumber > cfg.EndBlock {
+			break
+		}
+		if cfg.Mode == ModeSeconds && event.BlockNumber >= cfg.StartBlock && event.Timestamp == 0 {
+			return nil, fmt.Errorf("no timestamp for the event at block %d, rescan the events", event.BlockNumber)
+		}
+
+		for _, b := range holders {
+			cfg.settle(b, event.BlockNumber, event.Timestamp)
+		}
+
+		if !step.isShare {
+			if event.To == lp.Pair {
+				pairBalance = big.NewInt(0).Add(pairBalance, event.Amount)
+			}
+			if event.From == lp.Pair {
+				pairBalance = big.NewInt(0).Sub(pairBalance, event.Amount)
+			}
+			if pairBalance.Sign() < 0 {
+				return nil, fmt.Errorf("abnormal pair balance: pair %s, block %d, tx %s", lp.Pair, event.BlockNumber, event.TxHash)
+			}
+		} else {
+			if event.From == ZeroAddr {
+				supply = big.NewInt(0).Add(supply, event.Amount)
+			} else {
+				share := shares[event.From]
+				if share == nil || share.Cmp(event.Amount) < 0 {
+					return nil, fmt.Errorf("abnormal LP balance: address %s, block %d, tx %s", event.From, event.BlockNumber, event.TxHash)
+				}
+				shares[event.From] = big.NewInt(0).Sub(share, event.Amount)
+			}
+			if event.To == ZeroAddr {
+				// a mint to the zero address locks the minimum liquidity
+				if event.From != ZeroAddr {
+					supply = big.NewInt(0).Sub(supply, event.Amount)
+				}
+			} else if share, ok := shares[event.To]; ok {
+				shares[event.To] = big.NewInt(0).Add(share, event.Amount)
+			} else {
+				shares[event.To] = big.NewInt(0).Set(event.Amount)
+			}
+		}
+
+		for addr, share := range shares {
+			if addr == lp.Pair || !cfg.earns(addr) {
+				continue
+			}
+			b, ok := holders[addr]
+			if !ok {
+				b = cfg.newBalance(nil, event.BlockNumber, event.Timestamp)
+				holders[addr] = b
+			}
+			attributed := big.NewInt(0)
+			if supply.Sign() > 0 {
+				attributed.Mul(pairBalance, share).Quo(attributed, supply)
+			}
+			if err := cfg.hold(b, attributed, event.BlockNumber, event.Timestamp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for addr, b := range holders {
+		if b.BlockNumber < cfg.EndBlock {
+			cfg.settle(b, cfg.EndBlock, cfg.EndTime)
+		}
+		if b.CumulativeBalance.Sign() == 0 {
+			delete(holders, addr)
+		}
+	}
+	return holders, nil
+}
+
+func sortSteps(steps []lpStep) {
+	sort.SliceStable(steps, func(i, j int) bool {
+		return before(steps[i].event, steps[j].event)
+	})
+}
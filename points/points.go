@@ -0,0 +1,490 @@
+package points
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// BlocksPerDay is the number of blocks counted as one day of holding.
+const BlocksPerDay uint64 = 7200
+
+var ZeroAddr = common.HexToAddress("0x0000000000000000000000000000000000000000")
+
+// TransferEvent is a decoded ERC20 Transfer log. TxHash, LogIndex and
+// Timestamp are zero for events exported before they were recorded.
+type TransferEvent struct {
+	BlockNumber uint64
+	From        common.Address
+	To          common.Address
+	Amount      *big.Int
+	TxHash      common.Hash
+	LogIndex    uint
+	Timestamp   uint64
+}
+
+// BalanceInfo tracks the balance of one address and what it has accumulated
+// inside the round window: the balance×time, the sum of the sampled balances
+// or the lowest balance, depending on the Formula.
+type BalanceInfo struct {
+	Balance           *big.Int
+	BlockNumber       uint64
+	Timestamp         uint64
+	CumulativeBalance *big.Int
+	// Bonus is the part of CumulativeBalance earned by the loyalty multipliers.
+	Bonus *big.Int
+	// HeldSince is the block the address has held tokens since, without a full
+	// exit.
+	HeldSince uint64
+	// History lists every balance change of the address, oldest first.
+	History []BalanceChange
+
+	// heldTime is the timestamp of HeldSince.
+	heldTime uint64
+	// held tells whether CumulativeBalance holds a minimum yet.
+	held bool
+}
+
+// BalanceChange is one entry of the audit trail of an address.
+type BalanceChange struct {
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	LogIndex    uint        `json:"logIndex"`
+	// Amount is positive for credits and negative for debits.
+	Amount  *big.Int `json:"amount"`
+	Balance *big.Int `json:"balance"`
+	// Elapsed is the time Balance accrued inside the window until the next
+	// change, in the unit of the Mode or in sample blocks, and Points what it
+	// accrued, loyalty bonus included. Both are zero with FormulaMinBalance.
+	Elapsed uint64   `json:"elapsed"`
+	Points  *big.Int `json:"points"`
+}
+
+// Mode is the unit of time balances are accrued over.
+type Mode string
+
+const (
+	// ModeDays accrues balance × whole days of BlocksPerDay blocks, the formula
+	// of the past rounds. Holdings shorter than a day earn nothing.
+	ModeDays Mode = "days"
+	// ModeBlocks accrues balance × blocks.
+	ModeBlocks Mode = "blocks"
+	// ModeSeconds accrues balance × seconds between block timestamps.
+	ModeSeconds Mode = "seconds"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", ModeDays:
+		return ModeDays, nil
+	case ModeBlocks, ModeSeconds:
+		return Mode(s), nil
+	default:
+		return "", fmt.Errorf("unknown accrual mode %q", s)
+	}
+}
+
+// Config describes the round window and the addresses that need special
+// handling when classifying transfers.
+type Config struct {
+	StartBlock uint64
+	EndBlock   uint64
+	// Mode defaults to ModeDays.
+	Mode Mode
+	// Formula defaults to FormulaAccrued.
+	Formula Formula
+	// SampleBlocks are the sorted blocks sampled by FormulaSamples, see
+	// SampleBlocks.
+	SampleBlocks []uint64
+	// StartTime and EndTime are the timestamps of StartBlock and EndBlock,
+	// required by ModeSeconds.
+	StartTime uint64
+	EndTime   uint64
+
+	// Roles are the addresses handled specially, see Role. Other addresses are
+	// plain holders.
+	Roles map[common.Address]Role
+	// LPs attribute the balance of DEX pairs to their liquidity providers.
+	LPs []LP
+	// Bridged are the balance feeds of bridges.
+	Bridged []Bridged
+	// Snapshot seeds the balances at its block, at or before StartBlock; only
+	// the later events are replayed.
+	Snapshot *Snapshot
+	// Loyalty are the holding duration multipliers, sorted by days.
+	Loyalty []LoyaltyTier
+	// Lenient clamps debits exceeding the tracked balance and reports them as
+	// anomalies. A strict accrual fails with an AnomalyError.
+	Lenient bool
+}
+
+func (c Config) validate() error {
+	if c.EndBlock <= c.StartBlock {
+		return fmt.Errorf("invalid window: startBlock %d, endBlock %d", c.StartBlock, c.EndBlock)
+	}
+	if _, err := ParseMode(string(c.Mode)); err != nil {
+		return err
+	}
+	if c.Mode == ModeSeconds && c.EndTime <= c.StartTime {
+		return fmt.Errorf("invalid window: startTime %d, endTime %d", c.StartTime, c.EndTime)
+	}
+	if _, err := ParseFormula(string(c.Formula)); err != nil {
+		return err
+	}
+	if err := c.validateFormula(); err != nil {
+		return err
+	}
+	if err := c.validateLoyalty(); err != nil {
+		return err
+	}
+	if c.Snapshot != nil {
+		if c.Snapshot.BlockNumber > c.StartBlock {
+			return fmt.Errorf("snapshot block %d after startBlock %d", c.Snapshot.BlockNumber, c.StartBlock)
+		}
+		if len(c.LPs) > 0 {
+			return fmt.Errorf("LP attribution needs the full history, not a snapshot")
+		}
+		if len(c.Loyalty) > 0 {
+			return fmt.Errorf("loyalty multipliers need the full history, not a snapshot")
+		}
+	}
+	return nil
+}
+
+// is reports whether addr has one of the roles. A bridge with a balance feed
+// has the treasury role.
+func (c Config) is(addr common.Address, roles ...Role) bool {
+	role, ok := c.Roles[addr]
+	if !ok {
+		return false
+	}
+	if role == RoleBridge && c.isFed(addr) {
+		role = RoleTreasury
+	}
+	for _, r := range roles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpecial reports whether transfers of addr are not plain transfers.
+func (c Config) isSpecial(addr common.Address) bool {
+	return addr == ZeroAddr || c.is(addr, RolePool, RoleDex, RoleBridge, RoleTreasury)
+}
+
+// earns reports whether addr may earn points.
+func (c Config) earns(addr common.Address) bool {
+	return !c.isSpecial(addr) && !c.is(addr, RoleExcluded)
+}
+
+type balanceChange struct {
+	addr  common.Address
+	isAdd bool
+}
+
+// classify turns a transfer into the balance changes it causes.
+func (c Config) classify(event TransferEvent) []balanceChange {
+	changes := make([]balanceChange, 0, 2)
+
+	if event.From == ZeroAddr || c.is(event.From, RoleDex, RoleTreasury) { // mint & buy
+		changes = append(changes, balanceChange{addr: event.To, isAdd: true})
+	}
+
+	if event.To == ZeroAddr || c.is(event.To, RolePool, RoleDex, RoleTreasury) { // burn & sell
+		// pairs and bridges are never credited, so multi-hop swaps and bridge
+		// withdrawals into a pair debit nobody
+		if !c.isSpecial(event.From) {
+			changes = append(changes, balanceChange{addr: event.From, isAdd: false})
+		}
+	}
+
+	// ignore bridge transfer
+	if !c.isSpecial(event.From) && !c.isSpecial(event.To) { // transfer
+		changes = append(changes, balanceChange{addr: event.From, isAdd: false})
+		changes = append(changes, balanceChange{addr: event.To, isAdd: true})
+	}
+
+	return changes
+}
+
+// settle accrues the balance held from the last update up to block, whose
+// timestamp is given.
+func (c Config) settle(b *BalanceInfo, block, timestamp uint64) {
+	switch {
+	case c.Formula == FormulaMinBalance:
+		if since(b.BlockNumber, c.StartBlock, block) > 0 && (!b.held || b.Balance.Cmp(b.CumulativeBalance) < 0) {
+			b.CumulativeBalance = big.NewInt(0).Set(b.Balance)
+			b.held = true
+		}
+	case c.timed():
+		c.accrueLoyal(b, b.Timestamp, timestamp)
+	default:
+		c.accrueLoyal(b, b.BlockNumber, block)
+	}
+	b.BlockNumber = block
+	b.Timestamp = timestamp
+}
+
+// timed reports whether the balances accrue over timestamps rather than
+// blocks.
+func (c Config) timed() bool {
+	return c.Mode == ModeSeconds && c.Formula != FormulaSamples
+}
+
+// daily reports whether the balances accrue in whole days, ModeDays being the
+// default. The sampled and minimum balances are not accrued over time.
+func (c Config) daily() bool {
+	return c.Formula != FormulaSamples && c.Formula != FormulaMinBalance && c.Mode != ModeSeconds && c.Mode != ModeBlocks
+}
+
+// elapsed returns the time accrued over [from, to), from clamped to the window
+// start: blocks, whole days, seconds or sample blocks.
+func (c Config) elapsed(from, to uint64) uint64 {
+	switch {
+	case c.Formula == FormulaSamples:
+		return c.sampled(from, to)
+	case c.Mode == ModeSeconds:
+		return since(from, c.StartTime, to)
+	case c.Mode == ModeBlocks:
+		return since(from, c.StartBlock, to)
+	default:
+		return since(from, c.StartBlock, to) / BlocksPerDay
+	}
+}
+
+// since returns to - from, from clamped to the window start.
+func since(from, start, to uint64) uint64 {
+	if from < start {
+		from = start
+	}
+	if to <= from {
+		return 0
+	}
+	return to - from
+}
+
+// SortEvents sorts the events in (block, log index) order. Events of the same
+// block without log index keep their order.
+func SortEvents(events []TransferEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return before(events[i], events[j])
+	})
+}
+
+func before(a, b TransferEvent) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	return a.LogIndex < b.LogIndex
+}
+
+// Accrue replays the transfer events in (block, log index) order and returns
+// the balance info of every address touched up to the end of the window, and
+// the debits that exceeded the tracked balance. Such debits are clamped to the
+// balance; unless lenient, the accrual then fails with an AnomalyError listing
+// them all.
+func Accrue(events []TransferEvent, cfg Config) (map[common.Address]*BalanceInfo, []Anomaly, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	sorted := make([]TransferEvent, len(events))
+	copy(sorted, events)
+	SortEvents(sorted)
+
+	balance := map[common.Address]*BalanceInfo{}
+	if cfg.Snapshot != nil {
+		for addr, amount := range cfg.Snapshot.Balances {
+			b := cfg.newBalance(big.NewInt(0), cfg.Snapshot.BlockNumber, 0)
+			if err := cfg.hold(b, amount, cfg.Snapshot.BlockNumber, 0); err != nil {
+				return nil, nil, err
+			}
+			b.History = []BalanceChange{{
+				BlockNumber: cfg.Snapshot.BlockNumber,
+				Amount:      amount,
+				Balance:     amount,
+				Points:      big.NewInt(0),
+			}}
+			balance[addr] = b
+		}
+	}
+	anomalies := make([]Anomaly, 0)
+	// untracked are the addresses that received tokens crediting nobody
+	untracked := map[common.Address]bool{}
+	for _, event := range sorted {
+		if event.BlockNumber > cfg.EndBlock {
+			continue
+		}
+		if cfg.Snapshot != nil && event.BlockNumber <= cfg.Snapshot.BlockNumber {
+			continue
+		}
+		if cfg.Mode == ModeSeconds && event.BlockNumber >= cfg.StartBlock && event.Timestamp == 0 {
+			return nil, nil, fmt.Errorf("no timestamp for the event at block %d, rescan the events", event.BlockNumber)
+		}
+
+		changes := cfg.classify(event)
+		if !cfg.isSpecial(event.To) && !credits(changes, event.To) {
+			untracked[event.To] = true
+		}
+		for _, change := range changes {
+			b, ok := balance[change.addr]
+			if !ok {
+				b = cfg.newBalance(big.NewInt(0), event.BlockNumber, event.Timestamp)
+				balance[change.addr] = b
+			}
+
+			cfg.settle(b, event.BlockNumber, event.Timestamp)
+			amount := big.NewInt(0).Set(event.Amount)
+			if !change.isAdd {
+				if b.Balance.Cmp(event.Amount) < 0 {
+					anomalies = append(anomalies, Anomaly{
+						Address:     change.addr,
+						BlockNumber: event.BlockNumber,
+						TxHash:      event.TxHash,
+						LogIndex:    event.LogIndex,
+						From:        event.From,
+						To:          event.To,
+						Amount:      event.Amount,
+						Balance:     b.Balance,
+						Cause:       anomalyCause(b, untracked[change.addr]),
+					})
+					amount.Set(b.Balance)
+				}
+				amount.Neg(amount)
+			}
+			if err := cfg.hold(b, big.NewInt(0).Add(b.Balance, amount), event.BlockNumber, event.Timestamp); err != nil {
+				return nil, nil, err
+			}
+			b.History = append(b.History, BalanceChange{
+				BlockNumber: event.BlockNumber,
+				TxHash:      event.TxHash,
+				LogIndex:    event.LogIndex,
+				Amount:      amount,
+				Balance:     b.Balance,
+				Points:      big.NewInt(0),
+			})
+		}
+	}
+
+	for _, b := range balance {
+		if b.BlockNumber < cfg.EndBlock {
+			cfg.settle(b, cfg.EndBlock, cfg.EndTime)
+		}
+	}
+
+	if len(anomalies) > 0 && !cfg.Lenient {
+		return nil, anomalies, &AnomalyError{Anomalies: anomalies}
+	}
+	return balance, anomalies, nil
+}
+
+// credits reports whether the changes credit addr.
+func credits(changes []balanceChange, addr common.Address) bool {
+	for _, change := range changes {
+		if change.addr == addr && change.isAdd {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceTransfers is the source of the points earned on the own balance.
+const SourceTransfers = "transfers"
+
+// Sources accrues the events and returns the points of every address that
+// earned points, by source: SourceTransfers for its own balance, lp:<pair> for
+// the liquidity it provides, bridge:<source> for its bridged balance and
+// SourceLoyalty for the loyalty bonus on all of them. Points
+// are the cumulative balance in wei×days, wei×blocks or wei×seconds depending
+// on the mode, or a balance in wei with the other formulas; the minimum balance
+// is taken per source. Addresses with a role never earn points. The anomalies
+// are those of Accrue.
+func Sources(events []TransferEvent, cfg Config) (map[common.Address]map[string]*big.Int, []Anomaly, error) {
+	balance, anomalies, err := Accrue(events, cfg)
+	if err != nil {
+		return nil, anomalies, err
+	}
+	sources, err := SourcesOf(balance, events, cfg)
+	if err != nil {
+		return nil, anomalies, err
+	}
+	return sources, anomalies, nil
+}
+
+// SourcesOf returns the points by source of the balances accrued from the
+// events by Accrue, see Sources.
+func SourcesOf(balance map[common.Address]*BalanceInfo, events []TransferEvent, cfg Config) (map[common.Address]map[string]*big.Int, error) {
+	sources := make(map[common.Address]map[string]*big.Int)
+	add := func(addr common.Address, source string, point *big.Int) {
+		point = cfg.finish(point)
+		if point.Sign() == 0 {
+			return
+		}
+		if _, ok := sources[addr]; !ok {
+			sources[addr] = make(map[string]*big.Int)
+		}
+		if p, ok := sources[addr][source]; ok {
+			point = big.NewInt(0).Add(p, point)
+		}
+		sources[addr][source] = point
+	}
+
+	// addInfo adds the accrued balance of b, its loyalty bonus apart
+	addInfo := func(addr common.Address, source string, b *BalanceInfo) {
+		add(addr, source, big.NewInt(0).Sub(b.CumulativeBalance, b.Bonus))
+		add(addr, SourceLoyalty, b.Bonus)
+	}
+
+	for addr, b := range balance {
+		if cfg.earns(addr) {
+			addInfo(addr, SourceTransfers, b)
+		}
+	}
+	for _, lp := range cfg.LPs {
+		lpInfo, err := lpPoints(events, lp, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("LP of %s: %w", lp.Pair, err)
+		}
+		for addr, b := range lpInfo {
+			addInfo(addr, "lp:"+strings.ToLower(lp.Pair.Hex()), b)
+		}
+	}
+	for _, bridged := range cfg.Bridged {
+		bridgedInfo, err := bridgedPoints(bridged, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("bridged %s: %w", bridged.Source, err)
+		}
+		for addr, b := range bridgedInfo {
+			addInfo(addr, "bridge:"+bridged.Source, b)
+		}
+	}
+
+	return sources, nil
+}
+
+// Points returns the points of every address that earned points, adding up
+// its Sources.
+func Points(events []TransferEvent, cfg Config) (map[common.Address]*big.Int, error) {
+	sources, _, err := Sources(events, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return Sum(sources), nil
+}
+
+// Sum adds up the points of every address over its sources.
+func Sum(sources map[common.Address]map[string]*big.Int) map[common.Address]*big.Int {
+	points := make(map[common.Address]*big.Int, len(sources))
+	for addr, bySource := range sources {
+		point := big.NewInt(0)
+		for _, p := range bySource {
+			point = big.NewInt(0).Add(point, p)
+		}
+		points[addr] = point
+	}
+	return points
+}
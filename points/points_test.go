@@ -0,0 +1,606 @@
+package points
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+)
+
+var (
+	pool   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	dex    = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	bridge = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	alice  = common.HexToAddress("0x2000000000000000000000000000000000000001")
+	bob    = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	carol  = common.HexToAddress("0x2000000000000000000000000000000000000003")
+)
+
+var testConfig = Config{
+	StartBlock: 10000,
+	EndBlock:   10000 + 10*BlocksPerDay,
+	Roles: map[common.Address]Role{
+		pool:   RolePool,
+		dex:    RoleDex,
+		bridge: RoleBridge,
+	},
+}
+
+func transfer(block uint64, from, to common.Address, amount int64) TransferEvent {
+	return TransferEvent{BlockNumber: block, From: from, To: to, Amount: big.NewInt(amount)}
+}
+
+func TestPoints(t *testing.T) {
+	events := []TransferEvent{
+		transfer(100, ZeroAddr, alice, 100),                                // mint before the window
+		transfer(10000+2*BlocksPerDay, alice, bob, 40),                     // transfer
+		transfer(10000+4*BlocksPerDay, dex, bob, 10),                       // buy
+		transfer(10000+5*BlocksPerDay, alice, bridge, 60),                  // bridge, ignored
+		transfer(10000+6*BlocksPerDay, bob, pool, 50),                      // burn
+		transfer(10000+6*BlocksPerDay+BlocksPerDay-1, ZeroAddr, bob, 1000), // held less than a day
+		transfer(10000+6*BlocksPerDay+BlocksPerDay-1, bob, ZeroAddr, 1000),
+		transfer(10000+20*BlocksPerDay, ZeroAddr, alice, 1000), // after the window
+	}
+
+	pointInfo, err := Points(events, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[common.Address]int64{
+		alice: 100*2 + 60*8,
+		bob:   40*2 + 50*2,
+	}
+	if len(pointInfo) != len(expected) {
+		t.Fatalf("points length mismatch: got %v", pointInfo)
+	}
+	for addr, point := range expected {
+		if pointInfo[addr] == nil || pointInfo[addr].Int64() != point {
+			t.Errorf("points mismatch for %s: got %v, want %d", addr, pointInfo[addr], point)
+		}
+	}
+}
+
+func TestBreakdowns(t *testing.T) {
+	events := []TransferEvent{
+		transfer(100, ZeroAddr, alice, 100),
+		transfer(10000+2*BlocksPerDay, alice, bob, 40),
+		transfer(10000+5*BlocksPerDay, alice, bridge, 60), // ignored
+		transfer(10000+6*BlocksPerDay, bob, pool, 40),
+	}
+	balance, _, err := Accrue(events, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources, err := SourcesOf(balance, events, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdowns := Breakdowns(balance, sources, testConfig)
+	if len(breakdowns) != 2 || breakdowns[0].Address != alice || breakdowns[1].Address != bob {
+		t.Fatalf("unexpected breakdowns %+v", breakdowns)
+	}
+
+	expected := map[common.Address][][3]int64{ // balance, elapsed, points
+		alice: {{100, 2, 200}, {60, 8, 480}},
+		bob:   {{40, 4, 160}, {0, 4, 0}},
+	}
+	for _, breakdown := range breakdowns {
+		changes := expected[breakdown.Address]
+		if len(breakdown.Changes) != len(changes) {
+			t.Fatalf("%s: unexpected changes %+v", breakdown.Address, breakdown.Changes)
+		}
+		sum := big.NewInt(0)
+		for i, change := range breakdown.Changes {
+			if change.Balance.Int64() != changes[i][0] || change.Elapsed != uint64(changes[i][1]) || change.Points.Int64() != changes[i][2] {
+				t.Errorf("%s: change %d mismatch: %+v", breakdown.Address, i, change)
+			}
+			sum.Add(sum, change.Points)
+		}
+		if sum.Cmp(breakdown.Points) != 0 {
+			t.Errorf("%s: changes add up to %s, not %s", breakdown.Address, sum, breakdown.Points)
+		}
+	}
+	if breakdowns[0].HeldSince != 100 || breakdowns[1].HeldSince != 0 {
+		t.Errorf("unexpected held since %d, %d", breakdowns[0].HeldSince, breakdowns[1].HeldSince)
+	}
+}
+
+func TestAccrueAbnormalBalance(t *testing.T) {
+	events := []TransferEvent{
+		transfer(10001, ZeroAddr, alice, 10),
+		transfer(10002, alice, bob, 20),      // short of 10
+		transfer(10003, bob, ZeroAddr, 5),    // fine after the clamp
+		transfer(10004, pool, bob, 30),       // credits nobody
+		transfer(10005, bob, alice, 30),      // short of 15
+		transfer(10006, dex, alice, 50),      // buy
+		transfer(10007, bridge, bob, 1),      // credits nobody
+		transfer(10008, alice, ZeroAddr, 50), // fine
+		transfer(10009, carol, ZeroAddr, 1),  // never credited
+	}
+
+	_, anomalies, err := Accrue(events, testConfig)
+	if _, ok := err.(*AnomalyError); !ok {
+		t.Fatalf("expected anomaly error, got %v", err)
+	}
+	if len(anomalies) != 3 {
+		t.Fatalf("expected 3 anomalies, got %v", anomalies)
+	}
+
+	cfg := testConfig
+	cfg.Lenient = true
+	balance, anomalies, err := Accrue(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		addr    common.Address
+		block   uint64
+		balance int64
+		cause   string
+	}{
+		{addr: alice, block: 10002, balance: 10, cause: CauseUnknown},
+		{addr: bob, block: 10005, balance: 15, cause: CauseUnclassifiedContract},
+		{addr: carol, block: 10009, balance: 0, cause: CausePreWindowHistory},
+	}
+	if len(anomalies) != len(expected) {
+		t.Fatalf("expected %d anomalies, got %v", len(expected), anomalies)
+	}
+	for i, e := range expected {
+		a := anomalies[i]
+		if a.Address != e.addr || a.BlockNumber != e.block || a.Balance.Int64() != e.balance || a.Cause != e.cause {
+			t.Errorf("unexpected anomaly %d: %s", i, a)
+		}
+	}
+	// the receivers are credited in full
+	if balance[alice].Balance.Int64() != 30 || balance[bob].Balance.Sign() != 0 {
+		t.Errorf("unexpected clamped balances: alice %s, bob %s", balance[alice].Balance, balance[bob].Balance)
+	}
+}
+
+func TestAccrueLogOrder(t *testing.T) {
+	// the transfer is listed before the mint that funds it in the same block
+	send := transfer(10001, alice, bob, 10)
+	send.LogIndex, send.TxHash = 3, common.HexToHash("0x02")
+	mint := transfer(10001, ZeroAddr, alice, 10)
+	mint.LogIndex, mint.TxHash = 1, common.HexToHash("0x01")
+
+	balance, _, err := Accrue([]TransferEvent{send, mint}, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history := balance[alice].History
+	if len(history) != 2 {
+		t.Fatalf("expected 2 balance changes, got %d", len(history))
+	}
+	if history[0].TxHash != mint.TxHash || history[0].Amount.Int64() != 10 || history[0].Balance.Int64() != 10 {
+		t.Errorf("unexpected first change %+v", history[0])
+	}
+	if history[1].TxHash != send.TxHash || history[1].LogIndex != 3 || history[1].Amount.Int64() != -10 || history[1].Balance.Int64() != 0 {
+		t.Errorf("unexpected second change %+v", history[1])
+	}
+}
+
+func TestAccrueModes(t *testing.T) {
+	// 12s slots with a missed slot doubling the time of block 10102
+	timestamp := func(block uint64) uint64 {
+		if block >= 10102 {
+			return 1700000000 + (block-10000+1)*12
+		}
+		return 1700000000 + (block-10000)*12
+	}
+	event := func(block uint64, from, to common.Address, amount int64) TransferEvent {
+		e := transfer(block, from, to, amount)
+		e.Timestamp = timestamp(block)
+		return e
+	}
+	events := []TransferEvent{
+		event(9000, ZeroAddr, alice, 100),
+		event(10100, alice, bob, 40), // held for less than a day
+		event(10200, bob, pool, 40),
+	}
+	cfg := testConfig
+	cfg.EndBlock = 10300
+
+	cfg.Mode = ModeDays
+	pointInfo, err := Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pointInfo) != 0 {
+		t.Errorf("expected no points for holdings shorter than a day, got %v", pointInfo)
+	}
+
+	cfg.Mode = ModeBlocks
+	pointInfo, err = Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pointInfo[alice].Int64() != 100*100+60*200 || pointInfo[bob].Int64() != 40*100 {
+		t.Errorf("unexpected block points %v", pointInfo)
+	}
+
+	cfg.Mode = ModeSeconds
+	cfg.StartTime, cfg.EndTime = timestamp(cfg.StartBlock), timestamp(cfg.EndBlock)
+	pointInfo, err = Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pointInfo[alice].Int64() != 100*100*12+60*(200*12+12) || pointInfo[bob].Int64() != 40*(100*12+12) {
+		t.Errorf("unexpected second points %v", pointInfo)
+	}
+
+	events[1].Timestamp = 0
+	if _, err := Points(events, cfg); err == nil {
+		t.Error("expected missing timestamp error")
+	}
+}
+
+func TestFormulas(t *testing.T) {
+	events := []TransferEvent{
+		transfer(9000, ZeroAddr, alice, 100),
+		transfer(10100, alice, bob, 40),
+		transfer(10200, bob, pool, 40),
+		transfer(10250, ZeroAddr, carol, 1000), // flash deposit
+		transfer(10251, carol, ZeroAddr, 1000),
+	}
+	cfg := testConfig
+	cfg.EndBlock = 10300
+	cfg.Mode = ModeBlocks
+
+	cfg.Formula = FormulaTWAB
+	pointInfo, err := Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pointInfo[alice].Int64() != (100*100+60*200)/300 || pointInfo[bob].Int64() != 40*100/300 || pointInfo[carol].Int64() != 1000/300 {
+		t.Errorf("unexpected twab points %v", pointInfo)
+	}
+
+	cfg.Formula = FormulaSamples
+	cfg.SampleBlocks = []uint64{10000, 10150, 10260}
+	pointInfo, err = Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pointInfo) != 2 || pointInfo[alice].Int64() != (100+60+60)/3 || pointInfo[bob].Int64() != 40/3 {
+		t.Errorf("unexpected sample points %v", pointInfo)
+	}
+	cfg.SampleBlocks = []uint64{10150, 10000}
+	if _, err := Points(events, cfg); err == nil {
+		t.Error("expected unsorted sample blocks error")
+	}
+
+	cfg.Formula = FormulaMinBalance
+	pointInfo, err = Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pointInfo) != 1 || pointInfo[alice].Int64() != 60 {
+		t.Errorf("unexpected min balance points %v", pointInfo)
+	}
+}
+
+func TestSampleBlocks(t *testing.T) {
+	blocks, err := SampleBlocks(10000, 10300, 20, "round-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := SampleBlocks(10000, 10300, 20, "round-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 20 {
+		t.Fatalf("expected 20 blocks, got %v", blocks)
+	}
+	for i, block := range blocks {
+		if block < 10000 || block >= 10300 || (i > 0 && block <= blocks[i-1]) {
+			t.Fatalf("blocks not sorted, distinct and in the window: %v", blocks)
+		}
+		if again[i] != block {
+			t.Fatalf("blocks not deterministic: %v, %v", blocks, again)
+		}
+	}
+
+	other, err := SampleBlocks(10000, 10300, 20, "round-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := true
+	for i := range other {
+		same = same && other[i] == blocks[i]
+	}
+	if same {
+		t.Error("expected other blocks with another seed")
+	}
+
+	all, err := SampleBlocks(10000, 10010, 10, "round-4")
+	if err != nil || len(all) != 10 {
+		t.Errorf("expected every block of the window, got %v, %v", all, err)
+	}
+	if _, err := SampleBlocks(10000, 10010, 11, "round-4"); err == nil {
+		t.Error("expected too many samples error")
+	}
+}
+
+func TestLoyalty(t *testing.T) {
+	events := []TransferEvent{
+		transfer(10000-BlocksPerDay, ZeroAddr, alice, 100), // held for a day before the window
+		transfer(10000, ZeroAddr, bob, 10),
+		transfer(10000+3*BlocksPerDay, bob, pool, 10), // full exit
+		transfer(10000+4*BlocksPerDay, ZeroAddr, bob, 10),
+	}
+	cfg := testConfig
+	cfg.Mode = ModeBlocks
+	cfg.Loyalty = []LoyaltyTier{{Days: 2, Multiplier: 15000}, {Days: 5, Multiplier: 20000}}
+
+	sources, _, err := Sources(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := int64(BlocksPerDay)
+	expected := map[common.Address]map[string]int64{
+		// 1 day at 1×, 3 days at 1.5×, 6 days at 2×
+		alice: {SourceTransfers: 100 * 10 * day, SourceLoyalty: 100 * (3*day/2 + 6*day)},
+		// 2 days at 1×, 1 day at 1.5×, then held again from day 4: 2 days at
+		// 1×, 3 days at 1.5×, 1 day at 2×
+		bob: {SourceTransfers: 10 * 9 * day, SourceLoyalty: 10 * (day/2 + 3*day/2 + day)},
+	}
+	for addr, bySource := range expected {
+		for source, point := range bySource {
+			if sources[addr][source] == nil || sources[addr][source].Int64() != point {
+				t.Errorf("%s %s points mismatch: got %v, want %d", addr, source, sources[addr][source], point)
+			}
+		}
+	}
+
+	// whole days are counted before they are split between the tiers, so a
+	// tier never lowers the points
+	days := testConfig
+	days.EndBlock = 10000 + 3600 + 8640
+	days.Loyalty = []LoyaltyTier{{Days: 1, Multiplier: 12500}}
+	pointInfo, err := Points([]TransferEvent{
+		transfer(10000, ZeroAddr, alice, 100),
+		transfer(10000+3600, ZeroAddr, alice, 0),
+	}, days)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pointInfo[alice] == nil || pointInfo[alice].Int64() != 100 {
+		t.Errorf("unexpected day points %v", pointInfo)
+	}
+
+	// holding over seconds starts at a timestamp, even before the window
+	timestamp := func(block uint64) uint64 {
+		return 1700000000 + block*12
+	}
+	seconds := cfg
+	seconds.Mode = ModeSeconds
+	seconds.StartTime, seconds.EndTime = timestamp(seconds.StartBlock), timestamp(seconds.EndBlock)
+	timed := make([]TransferEvent, len(events))
+	for i, event := range events {
+		event.Timestamp = timestamp(event.BlockNumber)
+		timed[i] = event
+	}
+	if _, err := Points(timed, seconds); err != nil {
+		t.Fatal(err)
+	}
+	timed[0].Timestamp = 0
+	if _, err := Points(timed, seconds); err == nil {
+		t.Error("expected missing holding start timestamp error")
+	}
+
+	cfg.Loyalty = []LoyaltyTier{{Days: 2, Multiplier: 5000}}
+	if _, err := Points(events, cfg); err == nil {
+		t.Error("expected multiplier below 1× error")
+	}
+	cfg.Loyalty = []LoyaltyTier{{Days: 2, Multiplier: 15000}}
+	cfg.Formula = FormulaMinBalance
+	if _, err := Points(events, cfg); err == nil {
+		t.Error("expected min balance loyalty error")
+	}
+}
+
+func TestRoles(t *testing.T) {
+	treasury := common.HexToAddress("0x1000000000000000000000000000000000000004")
+	vault := common.HexToAddress("0x1000000000000000000000000000000000000005")
+	pair := common.HexToAddress("0x1000000000000000000000000000000000000006")
+	cfg := testConfig
+	cfg.Roles = map[common.Address]Role{
+		pool:     RolePool,
+		dex:      RoleDex,
+		pair:     RoleDex,
+		bridge:   RoleBridge,
+		treasury: RoleTreasury,
+		vault:    RoleExcluded,
+	}
+
+	events := []TransferEvent{
+		transfer(10000, treasury, alice, 100),               // treasury payout, minted
+		transfer(10000+2*BlocksPerDay, alice, vault, 60),    // plain transfer to an excluded address
+		transfer(10000+4*BlocksPerDay, vault, bob, 30),      // the excluded address holds a balance
+		transfer(10000+6*BlocksPerDay, bob, treasury, 30),   // returned to the treasury, burnt
+		transfer(10000+8*BlocksPerDay, alice, treasury, 40), // burnt
+		transfer(10000+8*BlocksPerDay, dex, pair, 500),      // multi-hop swap
+		transfer(10000+8*BlocksPerDay, bridge, pair, 20),    // bridge withdrawal into a pair
+	}
+
+	pointInfo, err := Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[common.Address]int64{
+		alice: 100*2 + 40*6,
+		bob:   30 * 2,
+	}
+	if len(pointInfo) != len(expected) {
+		t.Fatalf("points length mismatch: got %v", pointInfo)
+	}
+	for addr, point := range expected {
+		if pointInfo[addr] == nil || pointInfo[addr].Int64() != point {
+			t.Errorf("points mismatch for %s: got %v, want %d", addr, pointInfo[addr], point)
+		}
+	}
+
+	rules := &Rules{Rules: []Rule{{Address: pool, Role: "pool"}, {Address: pool, Role: "dex"}}}
+	if _, err := rules.Roles(); err == nil {
+		t.Error("expected duplicate rule error")
+	}
+}
+
+func TestLPAttribution(t *testing.T) {
+	cfg := testConfig
+	cfg.EndBlock = 10100
+	cfg.Mode = ModeBlocks
+	cfg.LPs = []LP{{
+		Pair: dex,
+		Events: []TransferEvent{
+			transfer(9000, ZeroAddr, alice, 100), // add liquidity
+			transfer(10050, alice, bob, 50),
+		},
+	}}
+
+	events := []TransferEvent{
+		transfer(8000, ZeroAddr, alice, 1000),
+		transfer(9000, alice, dex, 1000), // add liquidity
+		transfer(10060, dex, carol, 200), // buy
+	}
+
+	pointInfo, err := Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[common.Address]int64{
+		alice: 1000*50 + 500*10 + 400*40,
+		bob:   500*10 + 400*40,
+		carol: 200 * 40,
+	}
+	if len(pointInfo) != len(expected) {
+		t.Fatalf("points length mismatch: got %v", pointInfo)
+	}
+	for addr, point := range expected {
+		if pointInfo[addr] == nil || pointInfo[addr].Int64() != point {
+			t.Errorf("points mismatch for %s: got %v, want %d", addr, pointInfo[addr], point)
+		}
+	}
+
+	// the minimum liquidity locked by the zero address keeps its share
+	locked := cfg
+	locked.LPs = []LP{{
+		Pair: dex,
+		Events: []TransferEvent{
+			transfer(9000, ZeroAddr, ZeroAddr, 100),
+			transfer(9000, ZeroAddr, alice, 100),
+		},
+	}}
+	pointInfo, err = Points([]TransferEvent{
+		transfer(8000, ZeroAddr, carol, 1000),
+		transfer(9000, carol, dex, 1000),
+	}, locked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pointInfo) != 1 || pointInfo[alice] == nil || pointInfo[alice].Int64() != 500*100 {
+		t.Errorf("unexpected points with locked liquidity %v", pointInfo)
+	}
+
+	cfg.Mode = ModeDays
+	if _, err := Points(events, cfg); err == nil {
+		t.Error("expected LP attribution to need the blocks or seconds accrual")
+	}
+	cfg.Formula = FormulaSamples
+	cfg.SampleBlocks = []uint64{10050}
+	if _, err := Points(events, cfg); err != nil {
+		t.Errorf("unexpected error of sampled LP attribution: %v", err)
+	}
+}
+
+func TestBridged(t *testing.T) {
+	cfg := testConfig
+	cfg.Bridged = []Bridged{{
+		Source: "zklink",
+		Bridge: bridge,
+		Balances: []BridgedBalance{
+			{Address: alice, BlockNumber: 10000 + 2*BlocksPerDay, Balance: big.NewInt(60)},
+			{Address: alice, BlockNumber: 10000 + 8*BlocksPerDay, Balance: big.NewInt(0)},
+		},
+	}}
+
+	events := []TransferEvent{
+		transfer(9000, ZeroAddr, alice, 100),
+		transfer(10000+2*BlocksPerDay, alice, bridge, 60), // deposit, burnt on L1
+		transfer(10000+8*BlocksPerDay, bridge, bob, 60),   // withdrawal, minted on L1
+	}
+
+	sources, _, err := Sources(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 2 || len(sources[alice]) != 2 || len(sources[bob]) != 1 {
+		t.Fatalf("unexpected sources %v", sources)
+	}
+	if sources[alice][SourceTransfers].Int64() != 100*2+40*8 || sources[alice]["bridge:zklink"].Int64() != 60*6 {
+		t.Errorf("unexpected sources of alice %v", sources[alice])
+	}
+	if sources[bob][SourceTransfers].Int64() != 60*2 {
+		t.Errorf("unexpected sources of bob %v", sources[bob])
+	}
+
+	pointInfo, err := Points(events, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pointInfo[alice].Int64() != 100*2+40*8+60*6 {
+		t.Errorf("unexpected points of alice %v", pointInfo[alice])
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	events := []TransferEvent{
+		transfer(100, ZeroAddr, alice, 100),
+		transfer(200, alice, bob, 40),
+		transfer(300, bob, pool, 10),
+		transfer(10000+2*BlocksPerDay, alice, bob, 20),
+		transfer(10000+6*BlocksPerDay, bob, pool, 50),
+	}
+	full, err := Points(events, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := &Snapshot{BlockNumber: 9999, Balances: map[common.Address]*big.Int{
+		alice: big.NewInt(60),
+		bob:   big.NewInt(30),
+		pool:  big.NewInt(10),
+	}}
+	if mismatches := CheckSnapshot(events, testConfig, snapshot); len(mismatches) != 0 {
+		t.Fatalf("unexpected mismatches %v", mismatches)
+	}
+
+	// only the window events are replayed
+	cfg := testConfig
+	cfg.Snapshot = snapshot
+	seeded, err := Points(events[3:], cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seeded) != len(full) {
+		t.Fatalf("points length mismatch: got %v, want %v", seeded, full)
+	}
+	for addr, point := range full {
+		if seeded[addr] == nil || seeded[addr].Cmp(point) != 0 {
+			t.Errorf("points mismatch for %s: got %v, want %v", addr, seeded[addr], point)
+		}
+	}
+
+	cfg.Loyalty = []LoyaltyTier{{Days: 2, Multiplier: 15000}}
+	if _, err := Points(events[3:], cfg); err == nil {
+		t.Error("expected loyalty multipliers to need the full history")
+	}
+
+	snapshot.Balances[bob] = big.NewInt(31)
+	mismatches := CheckSnapshot(events, testConfig, snapshot)
+	if len(mismatches) != 1 || mismatches[0].Address != bob || mismatches[0].Replayed.Int64() != 30 {
+		t.Errorf("unexpected mismatches %v", mismatches)
+	}
+}
@@ -0,0 +1,101 @@
+package points
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"os"
+)
+
+// Role tells how transfers from and to an address are accounted.
+type Role string
+
+const (
+	// RolePool is a staking pool: transfers into it burn the sender's tokens,
+	// transfers out of it are ignored.
+	RolePool Role = "pool"
+	// RoleDex is a DEX pair: buys credit the buyer and sells debit the seller.
+	RoleDex Role = "dex"
+	// RoleBridge is a bridge: transfers from and to it are ignored.
+	RoleBridge Role = "bridge"
+	// RoleExcluded is an address that holds tokens like any other but never
+	// earns points.
+	RoleExcluded Role = "excluded"
+	// RoleTreasury is a source and sink of tokens like the zero address:
+	// transfers out of it mint to the receiver, transfers into it burn the
+	// sender's tokens.
+	RoleTreasury Role = "treasury"
+)
+
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case RolePool, RoleDex, RoleBridge, RoleExcluded, RoleTreasury:
+		return Role(s), nil
+	default:
+		return "", fmt.Errorf("unknown address role %q", s)
+	}
+}
+
+// Rule tags an address with a role.
+type Rule struct {
+	Address common.Address `json:"address"`
+	Role    string         `json:"role"`
+	// Label describes the address for the readers of the rules file.
+	Label string `json:"label"`
+	// LPToken is the LP share token of a DEX pair, the pair itself for a
+	// Uniswap V2 pair, used to attribute the pair balance to the LPs.
+	LPToken *common.Address `json:"lpToken,omitempty"`
+}
+
+// Rules is the rules file of a token.
+type Rules struct {
+	Token string `json:"token"`
+	Rules []Rule `json:"rules"`
+}
+
+// ReadRules reads a rules file.
+func ReadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &Rules{}
+	if err := json.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Roles returns the role of every address of the rules.
+func (r *Rules) Roles() (map[common.Address]Role, error) {
+	roles := make(map[common.Address]Role, len(r.Rules))
+	for _, rule := range r.Rules {
+		role, err := ParseRole(rule.Role)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rule.Address, err)
+		}
+		if rule.Address == ZeroAddr {
+			return nil, fmt.Errorf("the zero address cannot have a role")
+		}
+		if _, ok := roles[rule.Address]; ok {
+			return nil, fmt.Errorf("duplicate rule for %s", rule.Address)
+		}
+		if rule.LPToken != nil && role != RoleDex {
+			return nil, fmt.Errorf("%s: lpToken set on a %s", rule.Address, role)
+		}
+		roles[rule.Address] = role
+	}
+	return roles, nil
+}
+
+// LPTokens returns the LP token of every DEX pair that has one.
+func (r *Rules) LPTokens() map[common.Address]common.Address {
+	tokens := make(map[common.Address]common.Address)
+	for _, rule := range r.Rules {
+		if rule.LPToken != nil {
+			tokens[rule.Address] = *rule.LPToken
+		}
+	}
+	return tokens
+}
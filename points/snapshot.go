@@ -0,0 +1,106 @@
+package points
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"os"
+	"sort"
+)
+
+// Snapshot is the balanceOf of every holder of a token at a block. Accruing
+// from a snapshot seeds the balances at its block and only replays the later
+// events.
+type Snapshot struct {
+	Token       common.Address              `json:"token"`
+	BlockNumber uint64                      `json:"blockNumber"`
+	Balances    map[common.Address]*big.Int `json:"balances"`
+}
+
+// ReadSnapshot reads a snapshot file.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	for addr, balance := range snapshot.Balances {
+		if balance == nil || balance.Sign() < 0 {
+			return nil, fmt.Errorf("%s: invalid balance of %s", path, addr)
+		}
+	}
+	return snapshot, nil
+}
+
+// SnapshotMismatch is an address whose replayed balance differs from its
+// snapshot balance.
+type SnapshotMismatch struct {
+	Address  common.Address `json:"address"`
+	Snapshot *big.Int       `json:"snapshot"`
+	Replayed *big.Int       `json:"replayed"`
+}
+
+// CheckSnapshot replays the events up to the snapshot block and compares the
+// balances of the addresses that may earn points with the snapshot. Debits
+// exceeding the replayed balance are clamped.
+func CheckSnapshot(events []TransferEvent, cfg Config, snapshot *Snapshot) []SnapshotMismatch {
+	sorted := make([]TransferEvent, len(events))
+	copy(sorted, events)
+	SortEvents(sorted)
+
+	replayed := map[common.Address]*big.Int{}
+	for _, event := range sorted {
+		if event.BlockNumber > snapshot.BlockNumber {
+			break
+		}
+		for _, change := range cfg.classify(event) {
+			balance, ok := replayed[change.addr]
+			if !ok {
+				balance = big.NewInt(0)
+			}
+			if change.isAdd {
+				balance = big.NewInt(0).Add(balance, event.Amount)
+			} else if balance.Cmp(event.Amount) < 0 {
+				balance = big.NewInt(0)
+			} else {
+				balance = big.NewInt(0).Sub(balance, event.Amount)
+			}
+			replayed[change.addr] = balance
+		}
+	}
+
+	addrs := make(map[common.Address]bool)
+	for addr := range replayed {
+		addrs[addr] = true
+	}
+	for addr := range snapshot.Balances {
+		addrs[addr] = true
+	}
+
+	mismatches := make([]SnapshotMismatch, 0)
+	for addr := range addrs {
+		if !cfg.earns(addr) {
+			continue
+		}
+		expected, ok := snapshot.Balances[addr]
+		if !ok {
+			expected = big.NewInt(0)
+		}
+		actual, ok := replayed[addr]
+		if !ok {
+			actual = big.NewInt(0)
+		}
+		if expected.Cmp(actual) != 0 {
+			mismatches = append(mismatches, SnapshotMismatch{Address: addr, Snapshot: expected, Replayed: actual})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Address.Hex() < mismatches[j].Address.Hex()
+	})
+	return mismatches
+}
@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BlockFinder resolves timestamps to blocks with a binary search over block
+// headers. Resolved timestamps are kept in a JSON file, if any, so a round
+// resolves to the same blocks every time it runs, even without a client.
+type BlockFinder struct {
+	client Client
+	path   string
+	// blocks are the resolved blocks by unix timestamp
+	blocks map[uint64]BlockInfo
+}
+
+func NewBlockFinder(client Client, path string) (*BlockFinder, error) {
+	f := &BlockFinder{client: client, path: path, blocks: map[uint64]BlockInfo{}}
+	if path == "" {
+		return f, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &f.blocks); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// BlockAt returns the first block whose timestamp is at or after t.
+func (f *BlockFinder) BlockAt(ctx context.Context, t time.Time) (*BlockInfo, error) {
+	if t.Unix() < 0 {
+		return nil, fmt.Errorf("invalid time %s", t)
+	}
+	target := uint64(t.Unix())
+	if info, ok := f.blocks[target]; ok {
+		return &info, nil
+	}
+	if f.client == nil {
+		return nil, fmt.Errorf("no client to resolve %s", t.UTC().Format(time.RFC3339))
+	}
+
+	head, err := f.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found, err := f.client.BlockInfo(ctx, BlockTag(head))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(found.Timestamp) < target {
+		return nil, fmt.Errorf("no block at or after %s yet, head %d is at %s",
+			t.UTC().Format(time.RFC3339), head, time.Unix(int64(found.Timestamp), 0).UTC().Format(time.RFC3339))
+	}
+
+	// found is always the block at hi, the lowest block known to be late enough
+	lo, hi := uint64(0), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		info, err := f.client.BlockInfo(ctx, BlockTag(mid))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(info.Timestamp) >= target {
+			hi, found = mid, info
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	f.blocks[target] = *found
+	if err := f.save(); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (f *BlockFinder) save() error {
+	if f.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(f.blocks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.path+".tmp", f.path)
+}
@@ -0,0 +1,178 @@
+package scanner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/ethereum/go-ethereum/common"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CacheMeta records the block range of a token that has been fully scanned.
+type CacheMeta struct {
+	Token      common.Address `json:"token"`
+	StartBlock uint64         `json:"startBlock"`
+	EndBlock   uint64         `json:"endBlock"`
+	// Checkpoints are the hashes of the last block of every scanned window,
+	// used to detect that cached blocks were reorged out.
+	Checkpoints []Checkpoint `json:"checkpoints,omitempty"`
+}
+
+// Checkpoint is the hash of a scanned block.
+type Checkpoint struct {
+	Block uint64      `json:"block"`
+	Hash  common.Hash `json:"hash"`
+}
+
+// Cache stores the scanned Transfer events of every token in a JSONL file,
+// next to a meta file recording the scanned range. Events beyond the recorded
+// range were written by an interrupted scan and are ignored.
+type Cache struct {
+	dir string
+}
+
+func NewCache(dir string) (*Cache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("cache dir is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir}, nil
+}
+
+func (c *Cache) eventsPath(token common.Address) string {
+	return filepath.Join(c.dir, strings.ToLower(token.Hex())+".jsonl")
+}
+
+func (c *Cache) metaPath(token common.Address) string {
+	return filepath.Join(c.dir, strings.ToLower(token.Hex())+".json")
+}
+
+// Meta returns the scanned range of token, nil if nothing was scanned yet.
+func (c *Cache) Meta(token common.Address) (*CacheMeta, error) {
+	data, err := os.ReadFile(c.metaPath(token))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &CacheMeta{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", c.metaPath(token), err)
+	}
+	return meta, nil
+}
+
+// Load returns the cached events of token inside the scanned range.
+func (c *Cache) Load(token common.Address) ([]points.TransferEvent, *CacheMeta, error) {
+	meta, err := c.Meta(token)
+	if err != nil || meta == nil {
+		return nil, meta, err
+	}
+
+	events, err := ReadEvents(c.eventsPath(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scanned := make([]points.TransferEvent, 0, len(events))
+	for _, event := range events {
+		if event.BlockNumber >= meta.StartBlock && event.BlockNumber <= meta.EndBlock {
+			scanned = append(scanned, event)
+		}
+	}
+	return scanned, meta, nil
+}
+
+// Reset replaces the cache of token with the given events and range.
+func (c *Cache) Reset(meta CacheMeta, events []points.TransferEvent) error {
+	path := c.eventsPath(meta.Token)
+	if err := writeEvents(path+".tmp", events, os.O_CREATE|os.O_TRUNC|os.O_WRONLY); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	return c.writeMeta(meta)
+}
+
+// Append adds the events of the blocks up to the checkpoint block and extends
+// the scanned range. The events are written before the range so an
+// interruption never records a block as scanned without its events.
+func (c *Cache) Append(token common.Address, events []points.TransferEvent, checkpoint Checkpoint) error {
+	meta, err := c.Meta(token)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return fmt.Errorf("no cache for %s", token)
+	}
+
+	if err := writeEvents(c.eventsPath(token), events, os.O_CREATE|os.O_APPEND|os.O_WRONLY); err != nil {
+		return err
+	}
+	meta.EndBlock = checkpoint.Block
+	meta.Checkpoints = append(meta.Checkpoints, checkpoint)
+	return c.writeMeta(*meta)
+}
+
+func (c *Cache) writeMeta(meta CacheMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := c.metaPath(meta.Token)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func writeEvents(path string, events []points.TransferEvent, flag int) error {
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w (path: %s)", err, path)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// ReadEvents reads the events of a JSONL file.
+func ReadEvents(path string) ([]points.TransferEvent, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return []points.TransferEvent{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	events := make([]points.TransferEvent, 0)
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		event := points.TransferEvent{}
+		if err := dec.Decode(&event); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
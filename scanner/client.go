@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FinalizedTag selects the latest finalized block.
+const FinalizedTag = "finalized"
+
+// BlockTag returns the block tag of a block number.
+func BlockTag(number uint64) string {
+	return hexutil.EncodeUint64(number)
+}
+
+// BlockInfo is the part of a block header the scanner needs. The hash is taken
+// from the node rather than recomputed, so it stays right whatever fields newer
+// forks add to the header.
+type BlockInfo struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Hash      common.Hash    `json:"hash"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+}
+
+// Client is the subset of the node API used by the scanner.
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	// BlockInfo returns the block of a tag, a hex number or FinalizedTag.
+	BlockInfo(ctx context.Context, tag string) (*BlockInfo, error)
+}
+
+// RPCClient is an ethclient.Client that also serves BlockInfo.
+type RPCClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+func Dial(rawurl string) (*RPCClient, error) {
+	c, err := rpc.Dial(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return &RPCClient{Client: ethclient.NewClient(c), rpc: c}, nil
+}
+
+func (c *RPCClient) BlockInfo(ctx context.Context, tag string) (*BlockInfo, error) {
+	var info *BlockInfo
+	if err := c.rpc.CallContext(ctx, &info, "eth_getBlockByNumber", tag, false); err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
+	return info, nil
+}
@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"context"
+	"time"
+)
+
+// limiter spaces requests at most rps per second, shared by all workers.
+type limiter struct {
+	ticker *time.Ticker
+}
+
+func newLimiter(rps float64) *limiter {
+	if rps <= 0 {
+		return &limiter{}
+	}
+	return &limiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / rps))}
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if l.ticker == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *limiter) stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
@@ -0,0 +1,456 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	logging "github.com/ipfs/go-log/v2"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+var log = logging.Logger("scanner")
+
+var TransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// DefaultStep is the number of blocks fetched per eth_getLogs request.
+const DefaultStep uint64 = 20000
+
+const (
+	DefaultRetries     = 5
+	DefaultBackoff     = time.Second
+	DefaultConcurrency = 4
+	// DefaultConfirmations keeps the scan two epochs behind the head.
+	DefaultConfirmations = 64
+)
+
+// BlockRange is an inclusive range of blocks.
+type BlockRange struct {
+	From uint64
+	To   uint64
+}
+
+func (r BlockRange) String() string {
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// MissingRangesError lists the block ranges whose logs could not be fetched.
+type MissingRangesError struct {
+	Ranges []BlockRange
+}
+
+func (e *MissingRangesError) Error() string {
+	ranges := make([]string, len(e.Ranges))
+	for i, r := range e.Ranges {
+		ranges[i] = r.String()
+	}
+	return "failed to fetch logs of blocks " + strings.Join(ranges, ", ")
+}
+
+// rangeTooLargeErrors are the messages providers return when a query matches
+// too many logs or spans too many blocks. They must not match rate limit
+// errors, which are retried with backoff rather than split.
+var rangeTooLargeErrors = []string{
+	"more than 10000 results",
+	"query returned more than",
+	"response size exceeded",
+	"response size should not greater than",
+	"block range is too large",
+	"block range too large",
+	"range is too large",
+	"exceed maximum block range",
+	"block range limit exceeded",
+	"too many results",
+}
+
+func isRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range rangeTooLargeErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scanner fetches Transfer events into a cache, resuming from the last fully
+// scanned block. Windows of Step blocks are fetched by Concurrency workers,
+// limited to RPS requests per second overall. Failed requests are retried with
+// exponential backoff and ranges rejected as too large are split in halves.
+//
+// Scan stops Confirmations blocks behind the head, or at the finalized block
+// with Finalized. The hash of the last block of every window is cached, and a
+// later scan rescans the cached blocks whose hash no longer matches the chain.
+type Scanner struct {
+	client      Client
+	cache       *Cache
+	Step        uint64
+	Retries     int
+	Backoff     time.Duration
+	Concurrency int
+	// RPS is the request rate limit, 0 for none.
+	RPS           float64
+	Confirmations uint64
+	Finalized     bool
+}
+
+func New(client Client, cache *Cache) *Scanner {
+	return &Scanner{
+		client:  client,
+		cache:   cache,
+		Step:    DefaultStep,
+		Retries: DefaultRetries,
+		Backoff: DefaultBackoff,
+
+		Concurrency:   DefaultConcurrency,
+		Confirmations: DefaultConfirmations,
+	}
+}
+
+// Scan fetches the Transfer events of token from startBlock, or from where the
+// cache stopped, up to the confirmed head.
+func (s *Scanner) Scan(ctx context.Context, token common.Address, startBlock uint64) error {
+	toBlock, err := s.Head(ctx)
+	if err != nil {
+		return err
+	}
+	return s.ScanTo(ctx, token, startBlock, toBlock)
+}
+
+// Head returns the last block considered safe to scan: the finalized block with
+// Finalized, the head minus Confirmations otherwise.
+func (s *Scanner) Head(ctx context.Context) (uint64, error) {
+	if s.Finalized {
+		info, err := s.client.BlockInfo(ctx, FinalizedTag)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get finalized block: %w", err)
+		}
+		return uint64(info.Number), nil
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head < s.Confirmations {
+		return 0, fmt.Errorf("head %d below %d confirmations", head, s.Confirmations)
+	}
+	return head - s.Confirmations, nil
+}
+
+// ScanTo fetches the Transfer events of token from startBlock, or from where
+// the cache stopped, up to toBlock. The cache only advances up to the first
+// range that cannot be fetched; the scan goes on to list every such range in a
+// MissingRangesError.
+func (s *Scanner) ScanTo(ctx context.Context, token common.Address, startBlock, toBlock uint64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	limiter := newLimiter(s.RPS)
+	defer limiter.stop()
+
+	events, meta, err := s.cache.Load(token)
+	if err != nil {
+		return err
+	}
+	if meta != nil && meta.StartBlock <= startBlock {
+		events, meta, err = s.checkReorg(ctx, events, meta, limiter)
+		if err != nil {
+			return err
+		}
+	}
+
+	if meta == nil || meta.StartBlock > startBlock {
+		// nothing usable cached, start over from startBlock
+		meta = &CacheMeta{Token: token, StartBlock: startBlock, EndBlock: startBlock - 1}
+		events = nil
+	}
+	// drop the events of an interrupted scan before appending
+	if err := s.cache.Reset(*meta, events); err != nil {
+		return err
+	}
+
+	fromBlock := meta.EndBlock + 1
+	if fromBlock > toBlock {
+		log.Infow("cache up to date", "token", token, "endBlock", meta.EndBlock)
+		return nil
+	}
+	log.Infow("resume scan", "token", token, "fromBlock", fromBlock, "toBlock", toBlock)
+
+	windows := make([]BlockRange, 0)
+	for fromBlock <= toBlock {
+		nextBlock := fromBlock + s.Step - 1
+		if nextBlock > toBlock {
+			nextBlock = toBlock
+		}
+		windows = append(windows, BlockRange{From: fromBlock, To: nextBlock})
+		fromBlock = nextBlock + 1
+	}
+
+	results := s.fetchWindows(ctx, token, windows, limiter)
+
+	// consume the windows in block order, whatever order they complete in
+	missing := make([]BlockRange, 0)
+	for i, window := range windows {
+		var result windowResult
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result = <-results[i]:
+		}
+
+		if result.err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Errorw("missing block range", "fromBlock", window.From, "toBlock", window.To, "err", result.err)
+			missing = append(missing, window)
+		} else if len(missing) == 0 {
+			log.Infow("scan block", "fromBlock", window.From, "toBlock", window.To, "events", len(result.events))
+			checkpoint := Checkpoint{Block: window.To, Hash: result.hash}
+			if err := s.cache.Append(token, result.events, checkpoint); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingRangesError{Ranges: missing}
+	}
+	return nil
+}
+
+// checkReorg compares the cached checkpoints with the chain, newest first, and
+// truncates the cache after the newest one still on the chain: a matching hash
+// proves every block before it as well. It returns a nil meta when no
+// checkpoint matches anymore.
+func (s *Scanner) checkReorg(ctx context.Context, events []points.TransferEvent, meta *CacheMeta, limiter *limiter) ([]points.TransferEvent, *CacheMeta, error) {
+	if len(meta.Checkpoints) == 0 {
+		// cached before checkpoints were recorded, nothing to compare
+		return events, meta, nil
+	}
+
+	for i := len(meta.Checkpoints) - 1; i >= 0; i-- {
+		checkpoint := meta.Checkpoints[i]
+		info, err := s.blockInfo(ctx, checkpoint.Block, limiter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if info.Hash != checkpoint.Hash {
+			continue
+		}
+
+		if checkpoint.Block < meta.EndBlock {
+			log.Warnw("cached blocks reorged, rescanning", "token", meta.Token, "fromBlock", checkpoint.Block+1, "endBlock", meta.EndBlock)
+			kept := make([]points.TransferEvent, 0, len(events))
+			for _, event := range events {
+				if event.BlockNumber <= checkpoint.Block {
+					kept = append(kept, event)
+				}
+			}
+			events = kept
+			meta.EndBlock = checkpoint.Block
+			meta.Checkpoints = meta.Checkpoints[:i+1]
+		}
+		return events, meta, nil
+	}
+
+	log.Warnw("all cached blocks reorged, rescanning", "token", meta.Token, "startBlock", meta.StartBlock, "endBlock", meta.EndBlock)
+	return nil, nil, nil
+}
+
+type windowResult struct {
+	events []points.TransferEvent
+	hash   common.Hash
+	err    error
+}
+
+// fetchWindows fetches the windows with Concurrency workers. The result of
+// window i is delivered on the i-th channel.
+func (s *Scanner) fetchWindows(ctx context.Context, token common.Address, windows []BlockRange, limiter *limiter) []chan windowResult {
+	results := make([]chan windowResult, len(windows))
+	for i := range results {
+		results[i] = make(chan windowResult, 1)
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range windows {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- i:
+			}
+		}
+	}()
+
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			for i := range jobs {
+				// the hash is taken before the logs: if the window is reorged in
+				// between, the next scan sees the mismatch and rescans it
+				end, err := s.blockInfo(ctx, windows[i].To, limiter)
+				if err != nil {
+					results[i] <- windowResult{err: err}
+					continue
+				}
+				events, err := s.fetchTransfers(ctx, token, windows[i], limiter)
+				results[i] <- windowResult{events: events, hash: end.Hash, err: err}
+			}
+		}()
+	}
+
+	return results
+}
+
+// fetchTransfers returns the Transfer events of the window with the timestamps
+// of their blocks.
+func (s *Scanner) fetchTransfers(ctx context.Context, token common.Address, window BlockRange, limiter *limiter) ([]points.TransferEvent, error) {
+	logs, err := s.fetch(ctx, token, window.From, window.To, limiter)
+	if err != nil {
+		return nil, err
+	}
+	events, err := decodeTransfers(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make(map[uint64]uint64)
+	for i, event := range events {
+		timestamp, ok := timestamps[event.BlockNumber]
+		if !ok {
+			info, err := s.blockInfo(ctx, event.BlockNumber, limiter)
+			if err != nil {
+				return nil, err
+			}
+			timestamp = uint64(info.Timestamp)
+			timestamps[event.BlockNumber] = timestamp
+		}
+		events[i].Timestamp = timestamp
+	}
+	return events, nil
+}
+
+// fetch returns the Transfer logs of the range in (block, log index) order,
+// retrying failed requests and splitting the range when the provider rejects it
+// as too large.
+func (s *Scanner) fetch(ctx context.Context, token common.Address, fromBlock, toBlock uint64, limiter *limiter) ([]types.Log, error) {
+	var logs []types.Log
+	err := s.retry(ctx, limiter, func() error {
+		var err error
+		logs, err = s.client.FilterLogs(ctx, filterQuery(token, fromBlock, toBlock))
+		return err
+	}, "fromBlock", fromBlock, "toBlock", toBlock)
+	if err == nil {
+		sort.SliceStable(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+		return logs, nil
+	}
+
+	if isRangeTooLarge(err) && toBlock > fromBlock {
+		midBlock := fromBlock + (toBlock-fromBlock)/2
+		log.Infow("split block range", "fromBlock", fromBlock, "midBlock", midBlock, "toBlock", toBlock)
+		left, err := s.fetch(ctx, token, fromBlock, midBlock, limiter)
+		if err != nil {
+			return nil, err
+		}
+		right, err := s.fetch(ctx, token, midBlock+1, toBlock, limiter)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("failed to fetch logs %d-%d: %w", fromBlock, toBlock, err)
+}
+
+// blockInfo returns the header info of the block, retrying failed requests.
+func (s *Scanner) blockInfo(ctx context.Context, block uint64, limiter *limiter) (*BlockInfo, error) {
+	var info *BlockInfo
+	err := s.retry(ctx, limiter, func() error {
+		var err error
+		info, err = s.client.BlockInfo(ctx, BlockTag(block))
+		return err
+	}, "block", block)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get block %d: %w", block, err)
+	}
+	return info, nil
+}
+
+// retry calls f until it succeeds, at most Retries more times with exponential
+// backoff. Errors of ranges too large are returned at once, retrying them is
+// pointless.
+func (s *Scanner) retry(ctx context.Context, limiter *limiter, f func() error, keysAndValues ...interface{}) error {
+	var err error
+	for attempt := 0; attempt <= s.Retries; attempt++ {
+		if attempt > 0 {
+			backoff := s.Backoff << (attempt - 1)
+			log.Warnw("request failed, retrying", append(keysAndValues, "backoff", backoff, "err", err)...)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		if err = limiter.wait(ctx); err != nil {
+			return err
+		}
+		if err = f(); err == nil || isRangeTooLarge(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", s.Retries+1, err)
+}
+
+func filterQuery(token common.Address, fromBlock, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{token},
+		Topics:    [][]common.Hash{{TransferTopic}},
+	}
+}
+
+func decodeTransfers(logs []types.Log) ([]points.TransferEvent, error) {
+	transferEvents := make([]points.TransferEvent, 0, len(logs))
+	for _, l := range logs {
+		if len(l.Topics) != 3 || l.Topics[0] != TransferTopic {
+			return nil, fmt.Errorf("unexpected log in tx %s: %d topics", l.TxHash, len(l.Topics))
+		}
+
+		var from common.Address
+		copy(from[:], l.Topics[1][12:])
+		var to common.Address
+		copy(to[:], l.Topics[2][12:])
+		amount := big.NewInt(0).SetBytes(l.Data)
+		transferEvents = append(transferEvents, points.TransferEvent{
+			BlockNumber: l.BlockNumber,
+			From:        from,
+			To:          to,
+			Amount:      amount,
+			TxHash:      l.TxHash,
+			LogIndex:    l.Index,
+		})
+	}
+	return transferEvents, nil
+}
@@ -0,0 +1,452 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testToken = common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+// mockClient serves Transfer logs from memory.
+type mockClient struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+	// failAt makes the query starting at this block fail.
+	failAt uint64
+	// transient makes the first queries fail.
+	transient int
+	// rateLimited makes the first queries fail as rate limited.
+	rateLimited int
+	// maxRange rejects queries spanning more blocks as too large.
+	maxRange uint64
+	// fork changes the hash of the blocks from forkBlock on.
+	fork      byte
+	forkBlock uint64
+}
+
+func (c *mockClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *mockClient) BlockInfo(ctx context.Context, tag string) (*BlockInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	number := c.head
+	if tag != FinalizedTag {
+		n, err := hexutil.DecodeUint64(tag)
+		if err != nil {
+			return nil, err
+		}
+		number = n
+	}
+	fork := byte(0)
+	if number >= c.forkBlock {
+		fork = c.fork
+	}
+	return &BlockInfo{
+		Number:    hexutil.Uint64(number),
+		Timestamp: hexutil.Uint64(number * 12),
+		Hash:      crypto.Keccak256Hash(common.BigToHash(new(big.Int).SetUint64(number)).Bytes(), []byte{fork}),
+	}, nil
+}
+
+func (c *mockClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, q)
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if c.failAt != 0 && from == c.failAt {
+		return nil, fmt.Errorf("timeout")
+	}
+	if c.rateLimited > 0 {
+		c.rateLimited--
+		return nil, fmt.Errorf("429 Too Many Requests: rate limit exceeded")
+	}
+	if c.transient > 0 {
+		c.transient--
+		return nil, fmt.Errorf("connection reset")
+	}
+	if c.maxRange != 0 && to-from+1 > c.maxRange {
+		return nil, fmt.Errorf("query returned more than 10000 results")
+	}
+
+	logs := make([]types.Log, 0)
+	for _, l := range c.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func transferLog(block uint64, amount int64) types.Log {
+	return types.Log{
+		Address:     testToken,
+		Topics:      []common.Hash{TransferTopic, common.Hash{}, common.BigToHash(big.NewInt(int64(block)))},
+		Data:        common.BigToHash(big.NewInt(amount)).Bytes(),
+		BlockNumber: block,
+	}
+}
+
+func TestScanResume(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &mockClient{head: 250, logs: []types.Log{
+		transferLog(100, 1), transferLog(109, 2), transferLog(110, 3), transferLog(200, 4),
+	}}
+	s := New(client, cache)
+	s.Step = 10
+	s.Retries = 2
+	s.Backoff = time.Millisecond
+
+	client.failAt = 150
+	err = s.ScanTo(context.Background(), testToken, 100, 250)
+	missingErr, ok := err.(*MissingRangesError)
+	if !ok || len(missingErr.Ranges) != 1 || missingErr.Ranges[0] != (BlockRange{From: 150, To: 159}) {
+		t.Fatalf("expected missing range 150-159, got %v", err)
+	}
+	_, meta, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.EndBlock != 149 {
+		t.Fatalf("expected scan to stop at 149, got %d", meta.EndBlock)
+	}
+
+	client.failAt = 0
+	client.queries = nil
+	client.logs = append(client.logs, transferLog(260, 5))
+	client.head = 310
+	s.Confirmations = 10
+	if err := s.Scan(context.Background(), testToken, 100); err != nil {
+		t.Fatal(err)
+	}
+	first := client.queries[0].FromBlock.Uint64()
+	for _, q := range client.queries {
+		if q.FromBlock.Uint64() < first {
+			first = q.FromBlock.Uint64()
+		}
+	}
+	if first != 150 {
+		t.Fatalf("expected resume from 150, got %d", first)
+	}
+
+	events, meta, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.StartBlock != 100 || meta.EndBlock != 300 {
+		t.Fatalf("unexpected range %d-%d", meta.StartBlock, meta.EndBlock)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Amount.Int64() != int64(i+1) {
+			t.Fatalf("unexpected event %d: %+v", i, event)
+		}
+	}
+}
+
+func TestScanRetryAndSplit(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &mockClient{head: 1000, transient: 2, maxRange: 30, logs: []types.Log{
+		transferLog(100, 1), transferLog(150, 2), transferLog(199, 3), transferLog(1000, 4),
+	}}
+	s := New(client, cache)
+	s.Step = 100
+	s.Retries = 2
+	s.Backoff = time.Millisecond
+
+	if err := s.ScanTo(context.Background(), testToken, 100, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+	for _, q := range client.queries {
+		if span := q.ToBlock.Uint64() - q.FromBlock.Uint64() + 1; span > 100 {
+			t.Fatalf("unexpected query span %d", span)
+		}
+	}
+}
+
+func TestScanRateLimited(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &mockClient{head: 199, rateLimited: 2, logs: []types.Log{transferLog(150, 1)}}
+	s := New(client, cache)
+	s.Step = 100
+	s.Retries = 2
+	s.Backoff = 10 * time.Millisecond
+
+	start := time.Now()
+	if err := s.ScanTo(context.Background(), testToken, 100, 199); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("rate limited queries retried without backoff in %s", elapsed)
+	}
+	if len(client.queries) != 3 {
+		t.Fatalf("expected 3 queries, got %d", len(client.queries))
+	}
+	for _, q := range client.queries {
+		if q.FromBlock.Uint64() != 100 || q.ToBlock.Uint64() != 199 {
+			t.Errorf("rate limited range split into %d-%d", q.FromBlock.Uint64(), q.ToBlock.Uint64())
+		}
+	}
+}
+
+// slowClient answers the windows in reverse order and logs in reverse log
+// index order.
+type slowClient struct {
+	mockClient
+}
+
+func (c *slowClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(time.Duration(1000-q.FromBlock.Uint64()) * time.Microsecond * 20):
+	}
+	logs, err := c.mockClient.FilterLogs(ctx, q)
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	return logs, err
+}
+
+func TestScanConcurrentOrder(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &slowClient{}
+	for i := 0; i < 50; i++ {
+		l := transferLog(uint64(100+i/2*10), int64(i+1))
+		l.Index = uint(i % 2)
+		client.logs = append(client.logs, l)
+	}
+	s := New(client, cache)
+	s.Step = 10
+	s.Concurrency = 8
+	s.RPS = 10000
+
+	if err := s.ScanTo(context.Background(), testToken, 100, 399); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 50 {
+		t.Fatalf("expected 50 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Amount.Int64() != int64(i+1) {
+			t.Fatalf("event %d out of order: %+v", i, event)
+		}
+		if event.LogIndex != uint(i%2) || event.Timestamp != event.BlockNumber*12 {
+			t.Fatalf("unexpected log index or timestamp of event %d: %+v", i, event)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.ScanTo(ctx, testToken, 100, 800); err != context.Canceled {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestScanReorg(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &mockClient{head: 199, logs: []types.Log{
+		transferLog(100, 1), transferLog(125, 2), transferLog(185, 3),
+	}}
+	s := New(client, cache)
+	s.Step = 20
+
+	if err := s.ScanTo(context.Background(), testToken, 100, 199); err != nil {
+		t.Fatal(err)
+	}
+	_, meta, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Checkpoints) != 5 || meta.Checkpoints[4].Block != 199 {
+		t.Fatalf("unexpected checkpoints %+v", meta.Checkpoints)
+	}
+
+	// blocks from 150 on are replaced: the event at 185 moves to 190
+	client.fork, client.forkBlock = 1, 150
+	client.logs = []types.Log{transferLog(100, 1), transferLog(125, 2), transferLog(190, 4)}
+	client.queries = nil
+	if err := s.ScanTo(context.Background(), testToken, 100, 199); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range client.queries {
+		if q.FromBlock.Uint64() < 140 {
+			t.Fatalf("unexpected rescan from %d", q.FromBlock.Uint64())
+		}
+	}
+
+	events, meta, err := cache.Load(testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.EndBlock != 199 || len(meta.Checkpoints) != 5 {
+		t.Fatalf("unexpected meta %+v", meta)
+	}
+	if len(events) != 3 || events[2].BlockNumber != 190 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+
+	// every cached block reorged
+	client.fork, client.forkBlock = 2, 0
+	client.queries = nil
+	if err := s.ScanTo(context.Background(), testToken, 100, 199); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.queries) != 5 {
+		t.Fatalf("expected a full rescan, got %d queries", len(client.queries))
+	}
+}
+
+func TestHead(t *testing.T) {
+	client := &mockClient{head: 1000}
+	s := New(client, nil)
+	s.Confirmations = 12
+	head, err := s.Head(context.Background())
+	if err != nil || head != 988 {
+		t.Fatalf("expected 988, got %d, %v", head, err)
+	}
+
+	s.Finalized = true
+	head, err = s.Head(context.Background())
+	if err != nil || head != 1000 {
+		t.Fatalf("expected 1000, got %d, %v", head, err)
+	}
+}
+
+func TestBlockAt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocks.json")
+	client := &mockClient{head: 100000}
+	finder, err := NewBlockFinder(client, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// blocks are 12s apart, block n at n*12
+	for _, tc := range []struct {
+		unix  int64
+		block uint64
+	}{
+		{unix: 0, block: 0},
+		{unix: 12 * 5000, block: 5000},
+		{unix: 12*5000 + 1, block: 5001},
+		{unix: 12*100000 - 11, block: 100000},
+	} {
+		info, err := finder.BlockAt(context.Background(), time.Unix(tc.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uint64(info.Number) != tc.block {
+			t.Errorf("block at %d: expected %d, got %d", tc.unix, tc.block, info.Number)
+		}
+	}
+
+	if _, err := finder.BlockAt(context.Background(), time.Unix(12*100000+1, 0)); err == nil {
+		t.Error("expected error for a time after the head")
+	}
+
+	// resolved blocks are read back from the file, without a client
+	finder, err = NewBlockFinder(nil, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := finder.BlockAt(context.Background(), time.Unix(12*5000+1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Number != 5001 {
+		t.Errorf("expected cached block 5001, got %d", info.Number)
+	}
+	if _, err := finder.BlockAt(context.Background(), time.Unix(12*6000, 0)); err == nil {
+		t.Error("expected error resolving a new time without a client")
+	}
+}
+
+// mockCaller serves balanceOf from memory.
+type mockCaller struct {
+	token    common.Address
+	block    uint64
+	balances map[common.Address]*big.Int
+}
+
+func (c *mockCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if msg.To == nil || *msg.To != c.token || blockNumber.Uint64() != c.block {
+		return nil, fmt.Errorf("unexpected call")
+	}
+	if len(msg.Data) != 36 || !bytes.Equal(msg.Data[:4], balanceOfSelector) {
+		return nil, fmt.Errorf("execution reverted")
+	}
+	balance, ok := c.balances[common.BytesToAddress(msg.Data[4:])]
+	if !ok {
+		balance = big.NewInt(0)
+	}
+	return common.BigToHash(balance).Bytes(), nil
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	alice := common.HexToAddress("0x2000000000000000000000000000000000000001")
+	bob := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	caller := &mockCaller{token: testToken, block: 500, balances: map[common.Address]*big.Int{
+		alice: big.NewInt(1000),
+	}}
+
+	snapshot, err := TakeSnapshot(context.Background(), caller, testToken, []common.Address{alice, bob}, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.BlockNumber != 500 || len(snapshot.Balances) != 1 || snapshot.Balances[alice].Int64() != 1000 {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+
+	if _, err := TakeSnapshot(context.Background(), caller, testToken, []common.Address{alice}, 501); err == nil {
+		t.Error("expected call error")
+	}
+}
@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"github.com/bloxapp/ssv-rewards/points"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// balanceOfSelector is the selector of balanceOf(address).
+var balanceOfSelector = []byte{0x70, 0xa0, 0x82, 0x31}
+
+// Caller is the subset of the node API used to read balances. Historic blocks
+// need an archive node.
+type Caller interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// BalanceOf returns the token balance of holder at block.
+func BalanceOf(ctx context.Context, caller Caller, token, holder common.Address, block uint64) (*big.Int, error) {
+	data := make([]byte, 0, 36)
+	data = append(data, balanceOfSelector...)
+	data = append(data, common.LeftPadBytes(holder.Bytes(), 32)...)
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, new(big.Int).SetUint64(block))
+	if err != nil {
+		return nil, fmt.Errorf("balanceOf %s at block %d: %w", holder, block, err)
+	}
+	if len(out) != 32 {
+		return nil, fmt.Errorf("balanceOf %s at block %d: unexpected result %x", holder, block, out)
+	}
+	return new(big.Int).SetBytes(out), nil
+}
+
+// TakeSnapshot reads the balances of the holders at block, keeping the holders
+// with tokens.
+func TakeSnapshot(ctx context.Context, caller Caller, token common.Address, holders []common.Address, block uint64) (*points.Snapshot, error) {
+	snapshot := &points.Snapshot{Token: token, BlockNumber: block, Balances: map[common.Address]*big.Int{}}
+	for i, holder := range holders {
+		balance, err := BalanceOf(ctx, caller, token, holder, block)
+		if err != nil {
+			return nil, err
+		}
+		if balance.Sign() > 0 {
+			snapshot.Balances[holder] = balance
+		}
+		if (i+1)%100 == 0 {
+			log.Infow("snapshot progress", "holders", i+1, "of", len(holders))
+		}
+	}
+	return snapshot, nil
+}
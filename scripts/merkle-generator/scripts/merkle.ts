@@ -13,7 +13,10 @@ async function makeDrop() {
     const data = fs.readFileSync(path.join(__dirname, 'input_1.json'), 'utf-8');
 
     // Parse the JSON, converting large integers to BigInt
-    const leavesJson = JSON.parse(data);
+    const input = JSON.parse(data);
+
+    // Reward files with a schemaVersion keep the rewards in an envelope
+    const leavesJson = input.schemaVersion ? input.rewards : input;
 
     // Convert the JSON object to an array of [key, value] pairs
     const leavesArray = Object.entries(leavesJson);